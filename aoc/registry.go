@@ -0,0 +1,117 @@
+// Package aoc holds the registry of Advent of Code 2024 solutions.
+//
+// Each day's package registers its Solver from an init function, so importing
+// the day (or the days package, which imports all of them) is enough to make
+// it available to the runner.
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Day is a registered day's solver with its parsed input type erased, so that
+// every day can be run uniformly.
+type Day struct {
+	Number int
+
+	parse func(io.Reader) (any, error)
+	parts [2]func(context.Context, any) (Answer, error)
+
+	// stream is the solver's SolveStream method, if it is a StreamSolver.
+	stream func(ctx context.Context, part int, r io.Reader, memoryLimit int64) (Answer, error)
+}
+
+// Parts returns the parts the day can be solved for.
+func (d *Day) Parts() []int {
+	return []int{1, 2}
+}
+
+// Parse parses the raw puzzle input for the day's parts.
+func (d *Day) Parse(r io.Reader) (any, error) {
+	return d.parse(r)
+}
+
+// Part solves the given part (1 or 2) from input previously returned by Parse.
+// Cancelling ctx interrupts parts which support it (see Solver).
+func (d *Day) Part(ctx context.Context, part int, input any) (Answer, error) {
+	if part < 1 || part > len(d.parts) {
+		return Answer{}, fmt.Errorf("day %d has no part %d", d.Number, part)
+	}
+	return d.parts[part-1](ctx, input)
+}
+
+// Solve parses the raw puzzle input and solves the given part.
+func (d *Day) Solve(ctx context.Context, part int, r io.Reader) (Answer, error) {
+	input, err := d.Parse(r)
+	if err != nil {
+		return Answer{}, err
+	}
+	return d.Part(ctx, part, input)
+}
+
+// CanStream reports whether the day has a streaming mode (see StreamSolver).
+func (d *Day) CanStream() bool {
+	return d.stream != nil
+}
+
+// SolveStream solves the given part by streaming the raw puzzle input, with
+// about memoryLimit bytes of memory at most.
+func (d *Day) SolveStream(ctx context.Context, part int, r io.Reader, memoryLimit int64) (Answer, error) {
+	if d.stream == nil {
+		return Answer{}, fmt.Errorf("day %d has no streaming mode", d.Number)
+	}
+	if part < 1 || part > len(d.parts) {
+		return Answer{}, fmt.Errorf("day %d has no part %d", d.Number, part)
+	}
+	return d.stream(ctx, part, r, memoryLimit)
+}
+
+var registry = map[int]*Day{}
+
+// Register adds the solver for the given day to the registry.
+// It panics if the day has already been registered.
+func Register[T any](day int, solver Solver[T]) {
+	if day < 1 || day > 25 {
+		panic(fmt.Sprintf("aoc: invalid day %d", day))
+	}
+	if _, ok := registry[day]; ok {
+		panic(fmt.Sprintf("aoc: day %d registered twice", day))
+	}
+
+	d := &Day{
+		Number: day,
+		parse: func(r io.Reader) (any, error) {
+			return solver.Parse(r)
+		},
+		parts: [2]func(context.Context, any) (Answer, error){
+			func(ctx context.Context, input any) (Answer, error) { return solver.Part1(ctx, input.(T)) },
+			func(ctx context.Context, input any) (Answer, error) { return solver.Part2(ctx, input.(T)) },
+		},
+	}
+	if s, ok := solver.(StreamSolver); ok {
+		d.stream = s.SolveStream
+	}
+	registry[day] = d
+}
+
+// Lookup returns the registered solver for the given day.
+func Lookup(day int) (*Day, error) {
+	d, ok := registry[day]
+	if !ok {
+		return nil, fmt.Errorf("no solution registered for day %d", day)
+	}
+	return d, nil
+}
+
+// Days returns every registered day in ascending order.
+func Days() []int {
+	var days []int
+	for day := range registry {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
@@ -0,0 +1,107 @@
+package aoc
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type lengthSolver struct{}
+
+func (lengthSolver) Parse(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+func (lengthSolver) Part1(ctx context.Context, input string) (Answer, error) {
+	return IntAnswer(len(input)), nil
+}
+
+func (lengthSolver) Part2(ctx context.Context, input string) (Answer, error) {
+	return IntAnswer(2 * len(input)), nil
+}
+
+func TestRegistry(t *testing.T) {
+	// Use a fresh registry so this test doesn't depend on which days are imported.
+	saved := registry
+	registry = map[int]*Day{}
+	defer func() { registry = saved }()
+
+	Register[string](3, lengthSolver{})
+	Register[string](1, lengthSolver{})
+
+	if got, expected := Days(), []int{1, 3}; !slices.Equal(got, expected) {
+		t.Errorf("got days %v, expected %v", got, expected)
+	}
+
+	day, err := Lookup(3)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	answer, err := day.Solve(context.Background(), 2, strings.NewReader("abc"))
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if answer.String() != "6" {
+		t.Errorf("got answer %v, expected 6", answer)
+	}
+
+	if _, err := day.Part(context.Background(), 3, "abc"); err == nil {
+		t.Errorf("got nil error for unknown part, expected !nil")
+	}
+	if _, err := Lookup(2); err == nil {
+		t.Errorf("got nil error for unregistered day, expected !nil")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected duplicate registration to panic")
+		}
+	}()
+	Register[string](1, lengthSolver{})
+}
+
+// streamingLengthSolver is a lengthSolver which can also count the input's
+// length as it streams.
+type streamingLengthSolver struct {
+	lengthSolver
+}
+
+func (streamingLengthSolver) SolveStream(ctx context.Context, part int, r io.Reader, memoryLimit int64) (Answer, error) {
+	n, err := io.Copy(io.Discard, r)
+	return IntAnswer(int64(part) * n), err
+}
+
+func TestSolveStream(t *testing.T) {
+	saved := registry
+	registry = map[int]*Day{}
+	defer func() { registry = saved }()
+
+	Register[string](1, lengthSolver{})
+	Register[string](2, streamingLengthSolver{})
+
+	day, _ := Lookup(1)
+	if day.CanStream() {
+		t.Errorf("got CanStream() true for a solver without SolveStream, expected false")
+	}
+	if _, err := day.SolveStream(context.Background(), 1, strings.NewReader("abc"), 1<<20); err == nil {
+		t.Errorf("got nil error for a day without a streaming mode, expected !nil")
+	}
+
+	day, _ = Lookup(2)
+	if !day.CanStream() {
+		t.Errorf("got CanStream() false for a StreamSolver, expected true")
+	}
+	answer, err := day.SolveStream(context.Background(), 2, strings.NewReader("abc"), 1<<20)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if answer.String() != "6" {
+		t.Errorf("got answer %v, expected 6", answer)
+	}
+	if _, err := day.SolveStream(context.Background(), 3, strings.NewReader("abc"), 1<<20); err == nil {
+		t.Errorf("got nil error for unknown part, expected !nil")
+	}
+}
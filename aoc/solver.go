@@ -0,0 +1,110 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Answer is the answer to a single part of a puzzle.
+type Answer struct {
+	Value int64
+
+	// Meta holds optional details about how the answer was found, such as
+	// whether a simulation terminated. It is reported alongside the answer
+	// but is never part of what is submitted.
+	Meta map[string]any
+}
+
+// IntAnswer returns an Answer holding the given integer value.
+func IntAnswer[T ~int | ~int64 | ~uint | ~uint64](v T) Answer {
+	return Answer{Value: int64(v)}
+}
+
+// WithMeta returns a copy of the answer with the metadata key set to value.
+func (a Answer) WithMeta(key string, value any) Answer {
+	meta := make(map[string]any, len(a.Meta)+1)
+	for k, v := range a.Meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	a.Meta = meta
+	return a
+}
+
+// MetaString formats the answer's metadata in key order, e.g.
+// "(infinite_loop: false, steps: 5)", or returns "" if there is none.
+func (a Answer) MetaString() string {
+	if len(a.Meta) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(a.Meta))
+	for key := range a.Meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s: %v", key, a.Meta[key])
+	}
+	return "(" + strings.Join(pairs, ", ") + ")"
+}
+
+// String returns the answer as it would be submitted.
+func (a Answer) String() string {
+	return strconv.FormatInt(a.Value, 10)
+}
+
+// Solver is implemented by every day's solution.
+//
+// Parse is called once per input, and both parts are then solved from the
+// same parsed input, so neither part may modify it in a way that changes the
+// other part's answer.
+//
+// Parts which may run for a long time should check ctx periodically, and
+// return an Interrupted error (reporting their progress) once it is done.
+type Solver[T any] interface {
+	// Parse parses the raw puzzle input.
+	Parse(r io.Reader) (T, error)
+
+	// Part1 solves part 1 of the puzzle.
+	Part1(ctx context.Context, input T) (Answer, error)
+
+	// Part2 solves part 2 of the puzzle.
+	Part2(ctx context.Context, input T) (Answer, error)
+}
+
+// StreamSolver may also be implemented by a day's Solver, for inputs too
+// large to parse into memory. SolveStream solves a part by reading the raw
+// input as it goes, holding no more than about memoryLimit bytes in memory
+// (spilling the rest to temporary files).
+type StreamSolver interface {
+	SolveStream(ctx context.Context, part int, r io.Reader, memoryLimit int64) (Answer, error)
+}
+
+// InterruptedError is returned by a part which was cancelled before it
+// finished. It wraps the context's error, so errors.Is(err,
+// context.DeadlineExceeded) reports whether it timed out.
+type InterruptedError struct {
+	Progress string // how far the part got, e.g. "tested 120 of 5000 candidates"
+	Err      error
+}
+
+func (e *InterruptedError) Error() string {
+	return fmt.Sprintf("interrupted after %s: %v", e.Progress, e.Err)
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// Interrupted returns an *InterruptedError for a part cancelled by ctx, with
+// its progress formatted according to format.
+func Interrupted(ctx context.Context, format string, args ...any) error {
+	return &InterruptedError{Progress: fmt.Sprintf(format, args...), Err: ctx.Err()}
+}
@@ -0,0 +1,24 @@
+package aoc
+
+import "testing"
+
+func TestAnswerWithMeta(t *testing.T) {
+	answer := IntAnswer(41).WithMeta("infinite_loop", false)
+	withSteps := answer.WithMeta("steps", 5)
+
+	if answer.String() != "41" || withSteps.String() != "41" {
+		t.Errorf("got answers %v and %v, expected 41", answer, withSteps)
+	}
+	if len(answer.Meta) != 1 {
+		t.Errorf("got meta %v, expected WithMeta not to modify the original answer", answer.Meta)
+	}
+	if withSteps.Meta["infinite_loop"] != false || withSteps.Meta["steps"] != 5 {
+		t.Errorf("got meta %v, expected infinite_loop and steps", withSteps.Meta)
+	}
+	if got, expected := withSteps.MetaString(), "(infinite_loop: false, steps: 5)"; got != expected {
+		t.Errorf("got %q, expected %q", got, expected)
+	}
+	if got := IntAnswer(41).MetaString(); got != "" {
+		t.Errorf("got %q for an answer without metadata, expected \"\"", got)
+	}
+}
@@ -0,0 +1,36 @@
+package aoctest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+// Seed adds every input of the day in dataDir to the fuzz target's seed
+// corpus. Sealed inputs are skipped if there's no vault passphrase.
+//
+//	func FuzzParseMap(f *testing.F) {
+//		aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 6)
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			...
+//		})
+//	}
+func Seed(f *testing.F, dataDir string, day int) {
+	f.Helper()
+
+	inputs, err := manifest.InputFiles(dataDir, day)
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, input := range inputs {
+		data, err := vault.ReadFile(input)
+		if errors.Is(err, vault.ErrNoPassphrase) {
+			continue
+		} else if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+}
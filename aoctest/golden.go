@@ -0,0 +1,126 @@
+// Package aoctest provides test helpers for the days' solutions.
+//
+// Golden tests run every registered day against each file in its
+// challenge_data directory which has a sibling golden (".expected") file,
+// and compare the output with it:
+//
+//	func TestGolden(t *testing.T) {
+//		aoctest.Golden(t, filepath.Join("..", "challenge_data"))
+//	}
+//
+// A golden file must also agree with the answers recorded for its input in
+// the manifest (challenge_data/answers.json), so the two can't drift apart.
+//
+// Run the tests with -update to rewrite the golden files from the current
+// output. The manifest is never updated (it changes through aoc submit, aoc
+// examples, or by hand), so an output which disagrees with it still fails. To
+// add a golden file for an input, create an empty one and update (aoc
+// examples writes them for new examples).
+//
+// Fuzz targets are seeded with the same inputs by Seed.
+package aoctest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files from the current output")
+
+// Golden runs the golden tests of the given days (every registered day if
+// none are given) against the inputs in dataDir.
+func Golden(t *testing.T, dataDir string, days ...int) {
+	t.Helper()
+	if len(days) == 0 {
+		days = aoc.Days()
+	}
+
+	m, err := manifest.Load(filepath.Join(dataDir, manifest.DefaultFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, day := range days {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		goldens, err := filepath.Glob(filepath.Join(dataDir, fmt.Sprintf("day%d", day), "*"+manifest.GoldenExt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, golden := range goldens {
+			inputPath := strings.TrimSuffix(golden, manifest.GoldenExt)
+			key, err := manifest.Key(dataDir, inputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run(key, func(t *testing.T) {
+				input, err := vault.ReadFile(inputPath)
+				if errors.Is(err, vault.ErrNoPassphrase) {
+					t.Skip(err)
+				} else if err != nil {
+					t.Fatal(err)
+				}
+
+				got := manifest.GoldenOutput(d, input)
+				if *update {
+					if err := updateGolden(m, key, golden, got); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				expected, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != string(expected) {
+					t.Errorf("got:\n%s\nexpected (%s):\n%s", got, golden, expected)
+				}
+				for _, conflict := range manifestConflicts(m, key, string(expected)) {
+					t.Errorf("%s %s", golden, conflict)
+				}
+			})
+		}
+	}
+}
+
+// updateGolden rewrites a golden file with the output, unless the output
+// disagrees with the manifest: an update must not hide a wrong answer.
+func updateGolden(m manifest.Manifest, key string, golden string, output string) error {
+	if conflicts := manifestConflicts(m, key, output); len(conflicts) > 0 {
+		return fmt.Errorf("not updating %s: the output %s", golden, strings.Join(conflicts, "; "))
+	}
+	return os.WriteFile(golden, []byte(output), 0o644)
+}
+
+// manifestConflicts describes each answer of a golden file's output which
+// disagrees with the manifest.
+func manifestConflicts(m manifest.Manifest, key string, output string) []string {
+	var conflicts []string
+	answers := manifest.GoldenAnswers(output)
+	for part := 1; part <= 2; part++ {
+		expected, ok := m.Expected(key, part)
+		if !ok {
+			continue
+		}
+		if answer, ok := answers[part]; !ok {
+			conflicts = append(conflicts, fmt.Sprintf("has no answer for part %d, but the manifest expects %s", part, expected))
+		} else if answer != expected {
+			conflicts = append(conflicts, fmt.Sprintf("has part %d's answer as %s, but the manifest expects %s", part, answer, expected))
+		}
+	}
+	return conflicts
+}
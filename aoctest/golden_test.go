@@ -0,0 +1,145 @@
+package aoctest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+)
+
+// countSolver counts the lines of the input, and fails part 2 for empty input.
+type countSolver struct{}
+
+func (countSolver) Parse(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(data), "x") {
+		return nil, errors.New("unexpected x")
+	}
+	return strings.Fields(string(data)), nil
+}
+
+func (countSolver) Part1(ctx context.Context, input []string) (aoc.Answer, error) {
+	return aoc.IntAnswer(len(input)).WithMeta("empty", len(input) == 0), nil
+}
+
+func (countSolver) Part2(ctx context.Context, input []string) (aoc.Answer, error) {
+	if len(input) == 0 {
+		return aoc.Answer{}, errors.New("no lines")
+	}
+	v, _ := strconv.Atoi(input[0])
+	return aoc.IntAnswer(v), nil
+}
+
+func init() {
+	aoc.Register[[]string](25, countSolver{})
+}
+
+func TestGolden(t *testing.T) {
+	dataDir := t.TempDir()
+	dir := filepath.Join(dataDir, "day25")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"input_example":          "3\n4\n",
+		"input_example.expected": "part 1: 2 (empty: false)\npart 2: 3\n",
+		"input_no_golden":        "x",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	Golden(t, dataDir, 25)
+}
+
+func TestManifestConflicts(t *testing.T) {
+	m := manifest.Manifest{}
+	m.Set("day25/input_example", 1, "2")
+	m.Set("day25/input_example", 2, "3")
+
+	var tests = []struct {
+		output   string
+		expected int
+	}{
+		{"part 1: 2 (empty: false)\npart 2: 3\n", 0},
+		{"part 1: 2 (empty: false)\npart 2: 4\n", 1},
+		{"part 1: 2 (empty: false)\npart 2: error: no lines\n", 1},
+		{"parse error: unexpected x\n", 2},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			if conflicts := manifestConflicts(m, "day25/input_example", tt.output); len(conflicts) != tt.expected {
+				t.Errorf("got conflicts %q, expected %d", conflicts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	dataDir := t.TempDir()
+	dir := filepath.Join(dataDir, "day25")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "input_example"), []byte("5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join(dir, "input_example"+manifest.GoldenExt)
+	if err := os.WriteFile(golden, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(dataDir, manifest.DefaultFile)
+	m := manifest.Manifest{}
+	m.Set("day25/input_example", 2, "5")
+	if err := m.Save(manifestPath); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	*update = true
+	defer func() { *update = false }()
+	Golden(t, dataDir, 25)
+
+	if got, err := os.ReadFile(golden); err != nil || string(got) != "part 1: 1 (empty: false)\npart 2: 5\n" {
+		t.Errorf("got golden file %q (err: %v), expected the current output", got, err)
+	}
+	// The manifest is left as it is.
+	if got, err := os.ReadFile(manifestPath); err != nil || !bytes.Equal(got, saved) {
+		t.Errorf("got manifest %q (err: %v), expected %q", got, err, saved)
+	}
+}
+
+func TestUpdateGoldenConflict(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "input_example"+manifest.GoldenExt)
+	m := manifest.Manifest{}
+	m.Set("day25/input_example", 2, "4")
+
+	if err := updateGolden(m, "day25/input_example", golden, "part 1: 1 (empty: false)\npart 2: 5\n"); err == nil {
+		t.Errorf("got nil error for an output which disagrees with the manifest, expected !nil")
+	}
+	if _, err := os.Stat(golden); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got golden file written (err: %v), expected none", err)
+	}
+	if answer, _ := m.Expected("day25/input_example", 2); answer != "4" {
+		t.Errorf("got part 2 answer %q in the manifest, expected 4", answer)
+	}
+}
@@ -0,0 +1,146 @@
+// Package bench times the parse, part 1 and part 2 phases of a day's solution
+// separately, and keeps a history of the results keyed by git commit so
+// performance regressions can be spotted between changes.
+package bench
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+)
+
+// Phase names, in the order they are run.
+const (
+	PhaseParse = "parse"
+	PhasePart1 = "part1"
+	PhasePart2 = "part2"
+)
+
+// Measurement is the cost of a single run of a phase: the median duration
+// (which, unlike the mean, isn't thrown off by the odd slow run) and the
+// average allocations.
+type Measurement struct {
+	Phase       string        `json:"phase"`
+	Duration    time.Duration `json:"duration_ns"`
+	AllocsPerOp uint64        `json:"allocs_per_op"`
+	BytesPerOp  uint64        `json:"bytes_per_op"`
+}
+
+// Result holds the measurements of every phase of a day for a single input.
+type Result struct {
+	Day        int           `json:"day"`
+	Input      string        `json:"input"`
+	Iterations int           `json:"iterations"`
+	Phases     []Measurement `json:"phases"`
+}
+
+// Phase returns the measurement of the named phase.
+func (r *Result) Phase(name string) (Measurement, bool) {
+	for _, m := range r.Phases {
+		if m.Phase == name {
+			return m, true
+		}
+	}
+	return Measurement{}, false
+}
+
+// Run measures each phase of the day over the given number of iterations.
+//
+// Every part is run against freshly parsed input (which isn't measured), since
+// parts are allowed to modify their input in ways that would skew the timings
+// of later iterations, e.g. by sorting it.
+func Run(d *aoc.Day, inputName string, input []byte, iterations int) (*Result, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("iterations must be at least 1")
+	}
+
+	result := &Result{
+		Day:        d.Number,
+		Input:      inputName,
+		Iterations: iterations,
+	}
+
+	parse := func() (any, error) {
+		return d.Parse(bytes.NewReader(input))
+	}
+
+	m, err := measure(PhaseParse, iterations, func() error {
+		_, err := parse()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Phases = append(result.Phases, m)
+
+	for _, part := range d.Parts() {
+		var parsed any
+		m, err := measure(fmt.Sprintf("part%d", part), iterations, func() error {
+			_, err := d.Part(context.Background(), part, parsed)
+			return err
+		}, func() error {
+			var err error
+			parsed, err = parse()
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Phases = append(result.Phases, m)
+	}
+
+	return result, nil
+}
+
+// measure runs fn the given number of times, and takes the median of its
+// durations and the average of its allocations. The optional setup functions
+// are run (unmeasured) before each iteration.
+func measure(phase string, iterations int, fn func() error, setup ...func() error) (Measurement, error) {
+	durations := make([]time.Duration, 0, iterations)
+	var allocs, allocBytes uint64
+	var before, after runtime.MemStats
+
+	for i := 0; i < iterations; i++ {
+		for _, s := range setup {
+			if err := s(); err != nil {
+				return Measurement{}, fmt.Errorf("%s: %v", phase, err)
+			}
+		}
+
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err := fn()
+		durations = append(durations, time.Since(start))
+		runtime.ReadMemStats(&after)
+
+		if err != nil {
+			return Measurement{}, fmt.Errorf("%s: %v", phase, err)
+		}
+		allocs += after.Mallocs - before.Mallocs
+		allocBytes += after.TotalAlloc - before.TotalAlloc
+	}
+
+	n := uint64(iterations)
+	return Measurement{
+		Phase:       phase,
+		Duration:    median(durations),
+		AllocsPerOp: allocs / n,
+		BytesPerOp:  allocBytes / n,
+	}, nil
+}
+
+// median returns the median of the durations, which mustn't be empty.
+func median(durations []time.Duration) time.Duration {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
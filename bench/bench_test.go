@@ -0,0 +1,134 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+)
+
+type sortSolver struct{}
+
+func (sortSolver) Parse(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+func (sortSolver) Part1(ctx context.Context, input []byte) (aoc.Answer, error) {
+	// Mutates the input, so each iteration must get freshly parsed input.
+	if input[0] != 'b' {
+		panic("part 1 was given input modified by a previous iteration")
+	}
+	input[0] = 'a'
+	return aoc.IntAnswer(len(input)), nil
+}
+
+func (sortSolver) Part2(ctx context.Context, input []byte) (aoc.Answer, error) {
+	return aoc.IntAnswer(len(make([]byte, 1024))), nil
+}
+
+func TestRun(t *testing.T) {
+	aoc.Register[[]byte](25, sortSolver{})
+	d, err := aoc.Lookup(25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Run(d, "day25/input", []byte("ba"), 3)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+
+	var phases []string
+	for _, m := range result.Phases {
+		phases = append(phases, m.Phase)
+	}
+	if len(phases) != 3 || phases[0] != PhaseParse || phases[1] != PhasePart1 || phases[2] != PhasePart2 {
+		t.Errorf("got phases %v, expected parse, part1, part2", phases)
+	}
+
+	if _, err := Run(d, "day25/input", nil, 0); err == nil {
+		t.Errorf("got nil error for 0 iterations, expected !nil")
+	}
+}
+
+func TestMedian(t *testing.T) {
+	var tests = []struct {
+		durations []time.Duration
+		expected  time.Duration
+	}{
+		{[]time.Duration{5}, 5},
+		{[]time.Duration{9, 1, 5}, 5},
+		{[]time.Duration{1, 100, 2, 3}, 2},
+		{[]time.Duration{3, 1, 2, 1000}, 2},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			if got := median(tt.durations); got != tt.expected {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHistoryBaseline(t *testing.T) {
+	history := &History{Path: filepath.Join(t.TempDir(), "bench_history.jsonl")}
+
+	record := func(commit string, dirty bool, day int, duration time.Duration) Record {
+		return Record{
+			Commit: commit,
+			Dirty:  dirty,
+			Result: Result{
+				Day:    day,
+				Input:  "day6/input",
+				Phases: []Measurement{{Phase: PhasePart2, Duration: duration}},
+			},
+		}
+	}
+	err := history.Append(
+		record("aaaaaaa", false, 6, time.Second),
+		record("bbbbbbb", false, 6, 2*time.Second),
+		record("bbbbbbb", false, 5, 3*time.Second),
+		record("bbbbbbb", true, 6, 4*time.Second),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := history.Load()
+	if err != nil || len(records) != 4 {
+		t.Fatalf("got %d records (err: %v), expected 4", len(records), err)
+	}
+
+	var tests = []struct {
+		name     string
+		commit   string
+		dirty    bool
+		expected time.Duration
+	}{
+		{"new commit compares to latest", "ccccccc", false, 4 * time.Second},
+		{"same clean commit compares to previous commit", "bbbbbbb", false, time.Second},
+		{"modified commit compares to clean commit", "bbbbbbb", true, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := Baseline(records, tt.commit, tt.dirty, 6, "day6/input")
+			if baseline == nil {
+				t.Fatalf("got nil baseline")
+			}
+			m, _ := baseline.Phase(PhasePart2)
+			if m.Duration != tt.expected {
+				t.Errorf("got baseline duration %v, expected %v", m.Duration, tt.expected)
+			}
+		})
+	}
+
+	if Baseline(records, "aaaaaaa", false, 6, "day6/input_example") != nil {
+		t.Errorf("expected no baseline for an unknown input")
+	}
+}
@@ -0,0 +1,115 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Record is a benchmark result stored in the history.
+type Record struct {
+	Commit string    `json:"commit"`
+	Dirty  bool      `json:"dirty"`
+	Time   time.Time `json:"time"`
+	Result
+}
+
+// History is a local log of benchmark results, stored as one JSON object
+// per line so records are only ever appended.
+type History struct {
+	Path string
+}
+
+// Load returns every record, oldest first.
+// A missing history file is treated as an empty history.
+func (h *History) Load() ([]Record, error) {
+	file, err := os.Open(h.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []Record
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid record: %v", h.Path, lineNumber, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Append adds records to the history.
+func (h *History) Append(records ...Record) error {
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	return file.Close()
+}
+
+// Baseline returns the most recent record for the same day and input from a
+// different commit (or from the same commit before it was modified), which
+// new results should be compared against.
+func Baseline(records []Record, commit string, dirty bool, day int, input string) *Record {
+	for i := len(records) - 1; i >= 0; i-- {
+		r := &records[i]
+		if r.Day != day || r.Input != input {
+			continue
+		}
+		if r.Commit != commit || (dirty && !r.Dirty) {
+			return r
+		}
+	}
+	return nil
+}
+
+// GitCommit returns the current git commit of the working tree in dir, and
+// whether the tree has uncommitted changes.
+func GitCommit(dir string) (string, bool, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", false, fmt.Errorf("cannot determine git commit: %v", err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	out, err = exec.Command("git", "-C", dir, "status", "--porcelain", "--untracked-files=no").Output()
+	if err != nil {
+		return "", false, fmt.Errorf("cannot determine git status: %v", err)
+	}
+	return commit, strings.TrimSpace(string(out)) != "", nil
+}
@@ -0,0 +1,207 @@
+// Package cache stores solved answers locally, so re-running a day whose
+// code and input haven't changed doesn't solve it again.
+//
+// Answers are keyed by the day, the part, the SHA-256 of the input and the
+// version of the solver that produced them. Each answer is stored in its own
+// file, so concurrent runs never contend for a shared index.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a cached answer.
+type Entry struct {
+	Day           int            `json:"day"`
+	Part          int            `json:"part"`
+	InputSHA256   string         `json:"input_sha256"`
+	SolverVersion string         `json:"solver_version"`
+	Answer        string         `json:"answer"`
+	Meta          map[string]any `json:"meta,omitempty"`
+	DurationNS    int64          `json:"duration_ns"` // time originally taken to solve the part
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// key returns the file name the entry is stored under.
+func key(day int, part int, inputHash string, version string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d/%d/%s/%s", day, part, inputHash, version)))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// Cache is a directory of cached answers.
+type Cache struct {
+	Dir string
+}
+
+// Get returns the cached answer for a part, if there is one.
+func (c *Cache) Get(day int, part int, inputHash string, version string) (*Entry, bool, error) {
+	data, err := os.ReadFile(filepath.Join(c.Dir, key(day, part, inputHash, version)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		// A corrupt entry is a miss; it will be replaced by the next Put.
+		return nil, false, nil
+	}
+	return &entry, true, nil
+}
+
+// Put stores an answer, replacing any existing answer with the same key.
+func (c *Cache) Put(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(c.Dir, key(entry.Day, entry.Part, entry.InputSHA256, entry.SolverVersion)), data)
+}
+
+// Entries returns every cached answer, ordered by day, part and creation time.
+// A missing cache directory is treated as an empty cache.
+func (c *Cache) Entries() ([]Entry, error) {
+	paths, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("%s: invalid entry: %v", path, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Part != b.Part {
+			return a.Part < b.Part
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return entries, nil
+}
+
+// Clear removes the cached answers for which remove returns true (or every
+// answer, if remove is nil), and returns how many were removed.
+func (c *Cache) Clear(remove func(Entry) bool) (int, error) {
+	paths, err := c.files()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range paths {
+		if remove != nil {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return removed, err
+			}
+			var entry Entry
+			// Entries which can't be read are removed, as they'd never be used.
+			if err := json.Unmarshal(data, &entry); err == nil && !remove(entry) {
+				continue
+			}
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// files returns the paths of every entry in the cache.
+func (c *Cache) files() ([]string, error) {
+	dirEntries, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() && strings.HasSuffix(dirEntry.Name(), ".json") {
+			paths = append(paths, filepath.Join(c.Dir, dirEntry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// writeFileAtomic writes the file via a temporary file, so a concurrent Get
+// never sees a partially written entry.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+var (
+	versionOnce sync.Once
+	version     string
+	versionErr  error
+)
+
+// SolverVersion identifies the code of the running program: the SHA-256 of
+// its executable. Any change to the solutions (or anything else built into
+// the program) changes the version, so cached answers are never stale.
+func SolverVersion() (string, error) {
+	versionOnce.Do(func() {
+		path, err := os.Executable()
+		if err != nil {
+			versionErr = err
+			return
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			versionErr = err
+			return
+		}
+		defer file.Close()
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			versionErr = err
+			return
+		}
+		version = hex.EncodeToString(hash.Sum(nil))
+	})
+	return version, versionErr
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	c := &Cache{Dir: filepath.Join(t.TempDir(), "cache")}
+
+	if entries, err := c.Entries(); err != nil || len(entries) != 0 {
+		t.Errorf("got %v (err: %v) for a missing cache, expected no entries", entries, err)
+	}
+
+	entry := Entry{Day: 6, Part: 1, InputSHA256: "abc", SolverVersion: "v1", Answer: "41", CreatedAt: time.Now()}
+	if err := c.Put(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name      string
+		day       int
+		part      int
+		inputHash string
+		version   string
+		expected  bool
+	}{
+		{"same key", 6, 1, "abc", "v1", true},
+		{"different part", 6, 2, "abc", "v1", false},
+		{"different input", 6, 1, "abd", "v1", false},
+		{"different solver version", 6, 1, "abc", "v2", false},
+		{"different day", 5, 1, "abc", "v1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := c.Get(tt.day, tt.part, tt.inputHash, tt.version)
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if ok != tt.expected {
+				t.Fatalf("got hit %v, expected %v", ok, tt.expected)
+			}
+			if ok && got.Answer != "41" {
+				t.Errorf("got answer %q, expected 41", got.Answer)
+			}
+		})
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	c := &Cache{Dir: t.TempDir()}
+	for _, version := range []string{"v1", "v2", "v2"} {
+		for part := 1; part <= 2; part++ {
+			if err := c.Put(Entry{Day: 1, Part: part, InputSHA256: "abc", SolverVersion: version}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := os.WriteFile(filepath.Join(c.Dir, "corrupt.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := c.Clear(func(e Entry) bool { return e.SolverVersion != "v2" })
+	if err != nil || removed != 3 {
+		t.Errorf("got %d removed (err: %v), expected the 2 stale entries and the corrupt one", removed, err)
+	}
+	if entries, err := c.Entries(); err != nil || len(entries) != 2 || entries[0].Part != 1 || entries[1].Part != 2 {
+		t.Errorf("got %+v (err: %v), expected the two v2 entries ordered by part", entries, err)
+	}
+
+	if removed, err := c.Clear(nil); err != nil || removed != 2 {
+		t.Errorf("got %d removed (err: %v), expected 2", removed, err)
+	}
+}
+
+func TestSolverVersion(t *testing.T) {
+	first, err := SolverVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second, _ := SolverVersion(); len(first) != 64 || second != first {
+		t.Errorf("got versions %q and %q, expected the same SHA-256", first, second)
+	}
+}
@@ -0,0 +1,178 @@
+// Package client talks to the Advent of Code website on behalf of a logged in
+// user, identified by the value of their "session" cookie.
+//
+// Requests are throttled so that scripted use never hammers the site.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+
+	// DefaultMinInterval is the minimum time between two requests to the site.
+	DefaultMinInterval = 5 * time.Second
+
+	// Year is the Advent of Code event these solutions are for.
+	Year = 2024
+
+	userAgent = "github.com/Andoryuuta/AdventOfCode2024 (aoc command)"
+)
+
+// ErrNoSession is returned by LoadSession when no session cookie is configured.
+var ErrNoSession = errors.New("no session cookie configured (set AOC_SESSION or write it to the session file)")
+
+// Client is an Advent of Code website client. It is safe for concurrent use.
+type Client struct {
+	BaseURL     string
+	Session     string
+	HTTPClient  *http.Client
+	MinInterval time.Duration
+
+	mu          sync.Mutex
+	lastRequest time.Time
+
+	// Overridable for tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New returns a client for the Advent of Code website using the given session cookie.
+func New(session string) *Client {
+	return &Client{
+		BaseURL:     DefaultBaseURL,
+		Session:     session,
+		HTTPClient:  http.DefaultClient,
+		MinInterval: DefaultMinInterval,
+		now:         time.Now,
+		sleep:       sleepContext,
+	}
+}
+
+// SessionFile returns the default location of the session cookie file.
+func SessionFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "aoc", "session"), nil
+}
+
+// LoadSession returns the session cookie from the AOC_SESSION environment
+// variable, falling back to the session file.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv("AOC_SESSION")); session != "" {
+		return session, nil
+	}
+
+	path, err := SessionFile()
+	if err != nil {
+		return "", ErrNoSession
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	} else if err != nil {
+		return "", err
+	}
+
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", ErrNoSession
+	}
+	return session, nil
+}
+
+// StatusError is returned when the site responds with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Sprintf("site rejected the session cookie (status %d)", e.StatusCode)
+	case http.StatusNotFound:
+		return "puzzle not found (is it unlocked yet?)"
+	case http.StatusTooManyRequests:
+		return "rate limited by the site, try again later"
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// do sends a request to the site, waiting for MinInterval to pass since the
+// previous request, and returns the response body of a 200 OK response.
+func (c *Client) do(ctx context.Context, method string, path string, contentType string, body io.Reader) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	if err := c.throttle(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{resp.StatusCode, strings.TrimSpace(string(data))}
+	}
+	return data, nil
+}
+
+// throttle blocks until MinInterval has passed since the previous request.
+func (c *Client) throttle(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.lastRequest.IsZero() {
+		wait := c.lastRequest.Add(c.MinInterval).Sub(c.now())
+		if wait > 0 {
+			if err := c.sleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+	}
+	c.lastRequest = c.now()
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
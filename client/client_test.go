@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+// newTestClient returns a client for a fake site which uses a fake clock, so
+// throttling can be observed without sleeping.
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *[]time.Duration) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	now := time.Date(2024, 12, 6, 5, 0, 0, 0, time.UTC)
+	var sleeps []time.Duration
+
+	c := New("test-session")
+	c.BaseURL = server.URL
+	c.HTTPClient = server.Client()
+	c.now = func() time.Time { return now }
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+		return nil
+	}
+	return c, &sleeps
+}
+
+func TestFetchCachedInput(t *testing.T) {
+	requests := 0
+	c, sleeps := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "test-session" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.UserAgent() != userAgent {
+			t.Errorf("got user agent %q, expected %q", r.UserAgent(), userAgent)
+		}
+
+		switch r.URL.Path {
+		case "/2024/day/1/input":
+			w.Write([]byte("3   4\n4   3\n"))
+		case "/2024/day/2/input":
+			w.Write([]byte("7 6 4 2 1\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	cache := &InputCache{Dir: t.TempDir()}
+
+	path, fetched, err := c.FetchCachedInput(context.Background(), cache, 2024, 1)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if !fetched {
+		t.Errorf("expected input to be fetched")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "3   4\n4   3\n" {
+		t.Errorf("got cached input %q (err: %v)", data, err)
+	}
+
+	meta, err := cache.Metadata(1)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if meta.Year != 2024 || meta.Day != 1 || meta.FetchedAt.IsZero() {
+		t.Errorf("got unexpected metadata %+v", meta)
+	}
+
+	// A cached input must never be fetched again.
+	if _, fetched, err := c.FetchCachedInput(context.Background(), cache, 2024, 1); err != nil || fetched {
+		t.Errorf("got fetched %v (err: %v), expected cached input to be used", fetched, err)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, expected 1", requests)
+	}
+
+	// The next download has to wait for the rate limit.
+	if _, _, err := c.FetchCachedInput(context.Background(), cache, 2024, 2); err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != DefaultMinInterval {
+		t.Errorf("got sleeps %v, expected a single %v wait", *sleeps, DefaultMinInterval)
+	}
+
+	// Inputs placed by hand count as cached, and a cached input from another year is rejected.
+	if _, fetched, err := c.FetchCachedInput(context.Background(), cache, 2024, 2); err != nil || fetched {
+		t.Errorf("got fetched %v (err: %v), expected cached input to be used", fetched, err)
+	}
+	if _, _, err := c.FetchCachedInput(context.Background(), cache, 2023, 1); err == nil {
+		t.Errorf("got nil error for cached input from another year, expected !nil")
+	}
+}
+
+func TestFetchCachedInputSealed(t *testing.T) {
+	requests := 0
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("3   4\n"))
+	}))
+	cache := &InputCache{Dir: t.TempDir()}
+
+	// After aoc vault seal, a fresh checkout only has the sealed input.
+	sealed := cache.InputPath(1) + vault.Ext
+	if err := os.MkdirAll(filepath.Dir(sealed), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sealed, []byte("sealed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cache.Has(1) {
+		t.Errorf("got Has false for a sealed input, expected true")
+	}
+	path, fetched, err := c.FetchCachedInput(context.Background(), cache, 2024, 1)
+	if err != nil || fetched {
+		t.Errorf("got fetched %v (err: %v), expected the sealed input to be used", fetched, err)
+	}
+	if path != cache.InputPath(1) {
+		t.Errorf("got path %q, expected %q", path, cache.InputPath(1))
+	}
+	if requests != 0 {
+		t.Errorf("got %d requests, expected none", requests)
+	}
+}
+
+func TestFetchDescription(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/day/6" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`<article class="day-desc"></article>`))
+	}))
+
+	page, err := c.FetchDescription(context.Background(), 2024, 6)
+	if err != nil || string(page) != `<article class="day-desc"></article>` {
+		t.Errorf("got page %q (err: %v)", page, err)
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	var tests = []struct {
+		name       string
+		session    string
+		statusCode int
+		expected   error
+	}{
+		{"no session", "", http.StatusOK, ErrNoSession},
+		{"bad session", "test-session", http.StatusBadRequest, &StatusError{StatusCode: http.StatusBadRequest}},
+		{"not unlocked", "test-session", http.StatusNotFound, &StatusError{StatusCode: http.StatusNotFound}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			c.Session = tt.session
+
+			_, err := c.FetchInput(context.Background(), 2024, 25)
+			var statusErr *StatusError
+			if expected, ok := tt.expected.(*StatusError); ok {
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != expected.StatusCode {
+					t.Errorf("got error %v, expected status %d", err, expected.StatusCode)
+				}
+			} else if !errors.Is(err, tt.expected) {
+				t.Errorf("got error %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
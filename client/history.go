@@ -0,0 +1,159 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Attempt is a single recorded answer submission.
+type Attempt struct {
+	Year        int       `json:"year"`
+	Day         int       `json:"day"`
+	Part        int       `json:"part"`
+	Answer      string    `json:"answer"`
+	Outcome     Outcome   `json:"outcome"`
+	SubmittedAt time.Time `json:"submitted_at"`
+
+	// WaitUntil is when the site will accept the next submission, if it asked us to wait.
+	WaitUntil time.Time `json:"wait_until,omitempty"`
+}
+
+// History is a local log of every answer submission, stored as one JSON
+// object per line so attempts are only ever appended.
+type History struct {
+	Path string
+}
+
+// Load returns every recorded attempt, oldest first.
+// A missing history file is treated as an empty history.
+func (h *History) Load() ([]Attempt, error) {
+	file, err := os.Open(h.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var attempts []Attempt
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var attempt Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &attempt); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid attempt: %v", h.Path, lineNumber, err)
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// Append records an attempt.
+func (h *History) Append(attempt Attempt) error {
+	data, err := json.Marshal(attempt)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// CheckSubmission returns an error explaining why submitting the answer would
+// be pointless given the previous attempts: the puzzle part is already
+// solved, the answer is already known to be wrong (including being above a
+// known too-high answer or below a known too-low answer), or the site asked
+// us to wait.
+func CheckSubmission(attempts []Attempt, year int, day int, part int, answer string, now time.Time) error {
+	value, numericErr := strconv.ParseInt(answer, 10, 64)
+
+	for _, attempt := range attempts {
+		if attempt.Year != year || attempt.Day != day || attempt.Part != part {
+			continue
+		}
+
+		if attempt.Outcome == OutcomeCorrect {
+			if attempt.Answer == answer {
+				return fmt.Errorf("already solved with answer %s", attempt.Answer)
+			}
+			return fmt.Errorf("already solved with a different answer (%s)", attempt.Answer)
+		}
+
+		if attempt.Outcome.IsWrong() && attempt.Answer == answer {
+			return fmt.Errorf("answer %s is already known to be wrong (%s)", answer, attempt.Outcome)
+		}
+
+		if attemptValue, err := strconv.ParseInt(attempt.Answer, 10, 64); err == nil && numericErr == nil {
+			if attempt.Outcome == OutcomeTooHigh && value >= attemptValue {
+				return fmt.Errorf("answer %s is known to be too high (%s was too high)", answer, attempt.Answer)
+			}
+			if attempt.Outcome == OutcomeTooLow && value <= attemptValue {
+				return fmt.Errorf("answer %s is known to be too low (%s was too low)", answer, attempt.Answer)
+			}
+		}
+
+		if now.Before(attempt.WaitUntil) {
+			return fmt.Errorf("the site asked to wait until %s before submitting again", attempt.WaitUntil.Local().Format(time.TimeOnly))
+		}
+	}
+
+	return nil
+}
+
+// SubmitRecorded submits an answer unless CheckSubmission rules it out, and
+// records the attempt in the history.
+func (c *Client) SubmitRecorded(ctx context.Context, history *History, year int, day int, part int, answer string) (*SubmitResult, error) {
+	attempts, err := history.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckSubmission(attempts, year, day, part, answer, c.now()); err != nil {
+		return nil, err
+	}
+
+	result, err := c.Submit(ctx, year, day, part, answer)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := Attempt{
+		Year:        year,
+		Day:         day,
+		Part:        part,
+		Answer:      answer,
+		Outcome:     result.Outcome,
+		SubmittedAt: c.now().UTC(),
+	}
+	if result.Wait > 0 {
+		attempt.WaitUntil = attempt.SubmittedAt.Add(result.Wait)
+	}
+	if err := history.Append(attempt); err != nil {
+		return nil, fmt.Errorf("answer submitted (%s) but not recorded: %v", result.Outcome, err)
+	}
+	return result, nil
+}
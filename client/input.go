@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+// FetchInput downloads the personal puzzle input for the given day.
+// Most callers want FetchCachedInput instead.
+func (c *Client) FetchInput(ctx context.Context, year int, day int) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), "", nil)
+}
+
+// FetchDescription downloads the puzzle description page for the given day.
+// Part 2 is only included once part 1 has been solved.
+func (c *Client) FetchDescription(ctx context.Context, year int, day int) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d", year, day), "", nil)
+}
+
+// InputMetadata describes when and for which puzzle an input was downloaded.
+type InputMetadata struct {
+	Year      int       `json:"year"`
+	Day       int       `json:"day"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// InputCache stores puzzle inputs on disk as <Dir>/dayN/input, with the
+// download metadata alongside in <Dir>/dayN/input.meta.json.
+type InputCache struct {
+	Dir string
+}
+
+// InputPath returns where the day's input is stored.
+func (c *InputCache) InputPath(day int) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("day%d", day), "input")
+}
+
+func (c *InputCache) metadataPath(day int) string {
+	return c.InputPath(day) + ".meta.json"
+}
+
+// Has reports whether the day's input is already stored, regardless of
+// whether it was downloaded or placed there by hand. A sealed input (see the
+// vault package) counts, even without its plaintext.
+func (c *InputCache) Has(day int) bool {
+	for _, path := range []string{c.InputPath(day), c.InputPath(day) + vault.Ext} {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Metadata returns the download metadata of a stored input.
+// It returns an error satisfying errors.Is(err, os.ErrNotExist) for inputs
+// which weren't downloaded.
+func (c *InputCache) Metadata(day int) (*InputMetadata, error) {
+	data, err := os.ReadFile(c.metadataPath(day))
+	if err != nil {
+		return nil, err
+	}
+
+	var meta InputMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("invalid input metadata for day %d: %v", day, err)
+	}
+	return &meta, nil
+}
+
+// Store saves a downloaded input and its metadata.
+func (c *InputCache) Store(meta InputMetadata, input []byte) error {
+	metaData, err := json.MarshalIndent(meta, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.InputPath(meta.Day)), 0o755); err != nil {
+		return err
+	}
+	if err := writeFileAtomic(c.InputPath(meta.Day), input); err != nil {
+		return err
+	}
+	return writeFileAtomic(c.metadataPath(meta.Day), append(metaData, '\n'))
+}
+
+// FetchCachedInput returns the path to the day's input, downloading it into
+// the cache first if it isn't already there. A cached input is never
+// downloaded again, even if it's only stored sealed (vault.ReadFile reads
+// the path either way). The returned bool reports whether a download happened.
+func (c *Client) FetchCachedInput(ctx context.Context, cache *InputCache, year int, day int) (string, bool, error) {
+	if cache.Has(day) {
+		meta, err := cache.Metadata(day)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", false, err
+		}
+		if meta != nil && meta.Year != year {
+			return "", false, fmt.Errorf("cached input for day %d is from %d, not %d", day, meta.Year, year)
+		}
+		return cache.InputPath(day), false, nil
+	}
+
+	input, err := c.FetchInput(ctx, year, day)
+	if err != nil {
+		return "", false, fmt.Errorf("error fetching input for day %d: %w", day, err)
+	}
+
+	meta := InputMetadata{
+		Year:      year,
+		Day:       day,
+		FetchedAt: c.now().UTC(),
+	}
+	if err := cache.Store(meta, input); err != nil {
+		return "", false, err
+	}
+	return cache.InputPath(day), true, nil
+}
+
+// writeFileAtomic writes the file via a temporary file, so that an
+// interrupted write never leaves a truncated input behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
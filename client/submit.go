@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is the site's verdict on a submitted answer.
+type Outcome string
+
+const (
+	OutcomeCorrect       Outcome = "correct"
+	OutcomeWrong         Outcome = "wrong"
+	OutcomeTooHigh       Outcome = "too-high"
+	OutcomeTooLow        Outcome = "too-low"
+	OutcomeWait          Outcome = "wait"
+	OutcomeAlreadySolved Outcome = "already-solved"
+	OutcomeUnknown       Outcome = "unknown"
+)
+
+// IsWrong reports whether the outcome means the answer is known to be wrong.
+func (o Outcome) IsWrong() bool {
+	return o == OutcomeWrong || o == OutcomeTooHigh || o == OutcomeTooLow
+}
+
+// SubmitResult is the parsed response to a submitted answer.
+type SubmitResult struct {
+	Outcome Outcome
+
+	// Wait is how long the site asks us to wait before the next submission.
+	Wait time.Duration
+
+	// Message is the text of the response, without any markup.
+	Message string
+}
+
+// Submit posts an answer for the given day and part, and parses the response.
+func (c *Client) Submit(ctx context.Context, year int, day int, part int, answer string) (*SubmitResult, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {answer},
+	}
+	body, err := c.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/%d/day/%d/answer", year, day),
+		"application/x-www-form-urlencoded",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitResponse(string(body)), nil
+}
+
+var (
+	articleRegexp = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegexp     = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp   = regexp.MustCompile(`\s+`)
+
+	// "You have 1m 23s left to wait." / "You have 48s left to wait."
+	leftToWaitRegexp = regexp.MustCompile(`(?i)you have (?:(\d+)m )?(\d+)s left to wait`)
+
+	// "... please wait one minute before trying again." / "... wait 5 minutes ..."
+	waitMinutesRegexp = regexp.MustCompile(`(?i)wait (one|\d+) minutes?`)
+)
+
+// ParseSubmitResponse parses the HTML page returned after submitting an answer.
+func ParseSubmitResponse(page string) *SubmitResult {
+	message := page
+	if match := articleRegexp.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = html.UnescapeString(tagRegexp.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spaceRegexp.ReplaceAllString(message, " "))
+
+	result := &SubmitResult{
+		Outcome: OutcomeUnknown,
+		Message: message,
+	}
+
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "that's the right answer"):
+		result.Outcome = OutcomeCorrect
+	case strings.Contains(lower, "that's not the right answer"):
+		result.Outcome = OutcomeWrong
+		if strings.Contains(lower, "your answer is too high") {
+			result.Outcome = OutcomeTooHigh
+		} else if strings.Contains(lower, "your answer is too low") {
+			result.Outcome = OutcomeTooLow
+		}
+	case strings.Contains(lower, "you gave an answer too recently"):
+		result.Outcome = OutcomeWait
+	case strings.Contains(lower, "you don't seem to be solving the right level"):
+		result.Outcome = OutcomeAlreadySolved
+	}
+
+	if match := leftToWaitRegexp.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		result.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if match := waitMinutesRegexp.FindStringSubmatch(message); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		result.Wait = time.Duration(minutes) * time.Minute
+	}
+
+	return result
+}
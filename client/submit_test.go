@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSubmitResponse(t *testing.T) {
+	var tests = []struct {
+		name            string
+		page            string
+		expectedOutcome Outcome
+		expectedWait    time.Duration
+	}{
+		{
+			"correct",
+			`<main><article><p>That's the right answer! You are <em>one gold star</em> closer to finding the Chief Historian. <a href="/2024/day/6#part2">[Continue to Part Two]</a></p></article></main>`,
+			OutcomeCorrect,
+			0,
+		},
+		{
+			"too high",
+			`<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2024/about">about page</a>, or you can ask for hints on the <a href="https://www.reddit.com/r/adventofcode/" target="_blank">subreddit</a>.  Please wait one minute before trying again. <a href="/2024/day/6">[Return to Day 6]</a></p></article>`,
+			OutcomeTooHigh,
+			time.Minute,
+		},
+		{
+			"too low",
+			`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`,
+			OutcomeTooLow,
+			5 * time.Minute,
+		},
+		{
+			"wrong",
+			`<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.</p></article>`,
+			OutcomeWrong,
+			time.Minute,
+		},
+		{
+			"answered too recently",
+			`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 23s left to wait. <a href="/2024/day/6">[Return to Day 6]</a></p></article>`,
+			OutcomeWait,
+			83 * time.Second,
+		},
+		{
+			"answered too recently, seconds only",
+			`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 48s left to wait.</p></article>`,
+			OutcomeWait,
+			48 * time.Second,
+		},
+		{
+			"already solved",
+			`<article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/6">[Return to Day 6]</a></p></article>`,
+			OutcomeAlreadySolved,
+			0,
+		},
+		{
+			"unrecognised",
+			`<html><body>Something else</body></html>`,
+			OutcomeUnknown,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseSubmitResponse(tt.page)
+			if result.Outcome != tt.expectedOutcome {
+				t.Errorf("got outcome %v, expected %v (message: %q)", result.Outcome, tt.expectedOutcome, result.Message)
+			}
+			if result.Wait != tt.expectedWait {
+				t.Errorf("got wait %v, expected %v", result.Wait, tt.expectedWait)
+			}
+		})
+	}
+}
+
+func TestSubmitRecorded(t *testing.T) {
+	var submitted []string
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/6/answer" || r.FormValue("level") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		answer := r.FormValue("answer")
+		submitted = append(submitted, answer)
+		switch answer {
+		case "41":
+			w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+		case "100":
+			w.Write([]byte(`<article><p>That's not the right answer; your answer is too high. Please wait one minute before trying again.</p></article>`))
+		default:
+			w.Write([]byte(`<article><p>That's not the right answer; your answer is too low. Please wait one minute before trying again.</p></article>`))
+		}
+	}))
+	history := &History{Path: filepath.Join(t.TempDir(), "submissions.jsonl")}
+	ctx := context.Background()
+
+	result, err := c.SubmitRecorded(ctx, history, 2024, 6, 1, "100")
+	if err != nil || result.Outcome != OutcomeTooHigh {
+		t.Fatalf("got %+v (err: %v), expected too high", result, err)
+	}
+
+	// The site asked us to wait a minute before the next attempt.
+	if _, err := c.SubmitRecorded(ctx, history, 2024, 6, 1, "41"); err == nil {
+		t.Errorf("got nil error while waiting, expected !nil")
+	}
+	c.now = func() time.Time { return time.Date(2024, 12, 6, 6, 0, 0, 0, time.UTC) }
+
+	// Known wrong answers are never resubmitted.
+	for _, answer := range []string{"100", "150"} {
+		if _, err := c.SubmitRecorded(ctx, history, 2024, 6, 1, answer); err == nil {
+			t.Errorf("got nil error resubmitting %s, expected !nil", answer)
+		}
+	}
+
+	result, err = c.SubmitRecorded(ctx, history, 2024, 6, 1, "41")
+	if err != nil || result.Outcome != OutcomeCorrect {
+		t.Fatalf("got %+v (err: %v), expected correct", result, err)
+	}
+	if _, err := c.SubmitRecorded(ctx, history, 2024, 6, 1, "41"); err == nil {
+		t.Errorf("got nil error resubmitting a solved part, expected !nil")
+	}
+
+	if len(submitted) != 2 {
+		t.Errorf("got submissions %v, expected only 2 to reach the site", submitted)
+	}
+
+	attempts, err := history.Load()
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if len(attempts) != 2 || attempts[0].Outcome != OutcomeTooHigh || attempts[0].WaitUntil.IsZero() || attempts[1].Outcome != OutcomeCorrect {
+		t.Errorf("got unexpected history %+v", attempts)
+	}
+}
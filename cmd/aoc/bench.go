@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/bench"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN input files")
+	iterations := fs.Int("iterations", 10, "number of times each phase is run (its median time is reported)")
+	historyPath := fs.String("history", filepath.Join(defaultStateDir, "bench_history.jsonl"), "benchmark history file")
+	noHistory := fs.Bool("no-history", false, "don't record the results in the history")
+	threshold := fs.Float64("threshold", 0.1, "relative slowdown (vs. the previous commit) reported as a regression")
+	failOnRegression := fs.Bool("fail-on-regression", false, "exit with an error if any phase regressed")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("usage: aoc bench [day|all]")
+	}
+
+	days := aoc.Days()
+	if len(positional) == 1 && !isAll(positional[0]) {
+		day, err := strconv.Atoi(positional[0])
+		if err != nil {
+			return fmt.Errorf("invalid day %q", positional[0])
+		}
+		days = []int{day}
+	}
+
+	history := &bench.History{Path: *historyPath}
+	previous, err := history.Load()
+	if err != nil {
+		return err
+	}
+	commit, dirty, err := bench.GitCommit(".")
+	if err != nil {
+		return err
+	}
+
+	var records []bench.Record
+	regressions := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tINPUT\tPHASE\tTIME\tALLOCS/OP\tBYTES/OP\tCHANGE")
+	for _, day := range days {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			return err
+		}
+
+		inputs, err := manifest.InputFiles(*dataDir, day)
+		if err != nil {
+			return err
+		}
+		for _, inputPath := range inputs {
+			key, err := manifest.Key(*dataDir, inputPath)
+			if err != nil {
+				return err
+			}
+			input, err := vault.ReadFile(inputPath)
+			if err != nil {
+				return err
+			}
+
+			result, err := bench.Run(d, key, input, *iterations)
+			if err != nil {
+				return fmt.Errorf("day %d (%s): %v", day, key, err)
+			}
+
+			baseline := bench.Baseline(previous, commit, dirty, day, key)
+			for _, m := range result.Phases {
+				change := "-"
+				if baseline != nil {
+					if old, ok := baseline.Phase(m.Phase); ok && old.Duration > 0 {
+						delta := float64(m.Duration-old.Duration) / float64(old.Duration)
+						change = fmt.Sprintf("%+.1f%% vs %s", delta*100, baseline.Commit)
+						if delta > *threshold {
+							change += " REGRESSION"
+							regressions++
+						}
+					}
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%v\t%d\t%d\t%s\n",
+					day, key, m.Phase, m.Duration.Round(time.Microsecond), m.AllocsPerOp, m.BytesPerOp, change)
+			}
+
+			records = append(records, bench.Record{
+				Commit: commit,
+				Dirty:  dirty,
+				Time:   time.Now().UTC(),
+				Result: *result,
+			})
+		}
+	}
+	w.Flush()
+
+	if !*noHistory {
+		if err := history.Append(records...); err != nil {
+			return err
+		}
+	}
+
+	if regressions > 0 && *failOnRegression {
+		return fmt.Errorf("%d phases slower than the previous commit by more than %.0f%%", regressions, *threshold*100)
+	}
+	return nil
+}
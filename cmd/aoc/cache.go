@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/cache"
+)
+
+func cacheCommand(args []string) error {
+	fs := flag.NewFlagSet("cache", flag.ContinueOnError)
+	cacheDir := fs.String("cache", defaultCacheDir, "directory of cached answers")
+	stale := fs.Bool("stale", false, "only clear answers from other versions of the solvers")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: aoc cache <list|clear> [--stale]")
+	}
+
+	answers := &cache.Cache{Dir: *cacheDir}
+	version, err := cache.SolverVersion()
+	if err != nil {
+		return err
+	}
+
+	switch positional[0] {
+	case "list":
+		entries, err := answers.Entries()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DAY\tPART\tANSWER\tTIME\tINPUT\tSOLVER\tCREATED")
+		for _, entry := range entries {
+			solver := "current"
+			if entry.SolverVersion != version {
+				solver = "stale"
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%v\t%s\t%s\t%s\n", entry.Day, entry.Part, entry.Answer,
+				time.Duration(entry.DurationNS).Round(time.Microsecond), shortHash(entry.InputSHA256), solver,
+				entry.CreatedAt.Local().Format(time.DateTime))
+		}
+		w.Flush()
+		fmt.Printf("\n%d cached answers in %s\n", len(entries), *cacheDir)
+		return nil
+
+	case "clear":
+		var remove func(cache.Entry) bool
+		if *stale {
+			remove = func(entry cache.Entry) bool { return entry.SolverVersion != version }
+		}
+		removed, err := answers.Clear(remove)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("removed %d cached answers\n", removed)
+		return nil
+	}
+
+	return fmt.Errorf("unknown cache command %q (expected list or clear)", positional[0])
+}
+
+// shortHash abbreviates a hex-encoded hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/external"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+func compareCommand(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	configPath := fs.String("config", external.DefaultFile, "external solver config")
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN input files")
+	timeout := fs.Duration("timeout", time.Minute, "time limit for each external solver run")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("usage: aoc compare [day|all] [--config file]")
+	}
+
+	config, err := external.Load(*configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		// Solvers in other languages are optional, and declared locally.
+		fmt.Printf("No external solvers to compare: %s doesn't exist.\n", *configPath)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	days := aoc.Days()
+	if len(positional) == 1 && !isAll(positional[0]) {
+		day, err := strconv.Atoi(positional[0])
+		if err != nil {
+			return fmt.Errorf("invalid day %q", positional[0])
+		}
+		days = []int{day}
+	}
+
+	counts := map[string]int{}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tPART\tINPUT\tSOLVER\tANSWER\tGO\tSTATUS")
+	for _, day := range days {
+		solvers := config.ForDay(day)
+		if len(solvers) == 0 {
+			continue
+		}
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			return err
+		}
+
+		inputs, err := manifest.InputFiles(*dataDir, day)
+		if err != nil {
+			return err
+		}
+		for _, inputPath := range inputs {
+			key, err := manifest.Key(*dataDir, inputPath)
+			if err != nil {
+				return err
+			}
+			goAnswers := solveGo(d, inputPath)
+
+			for _, s := range solvers {
+				answers, err := solveExternal(s, inputPath, *timeout)
+				for _, part := range s.Parts {
+					var answer, status string
+					switch {
+					case err != nil:
+						answer, status = err.Error(), "error"
+					case answers[part] == goAnswers[part]:
+						answer, status = answers[part], "match"
+					default:
+						answer, status = answers[part], "mismatch"
+					}
+					counts[status]++
+					fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n", day, part, key, s.Name, answer, goAnswers[part], status)
+				}
+			}
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d match, %d mismatch, %d errors\n", counts["match"], counts["mismatch"], counts["error"])
+	if counts["mismatch"] > 0 || counts["error"] > 0 {
+		return fmt.Errorf("%d external answers differ or failed", counts["mismatch"]+counts["error"])
+	}
+	return nil
+}
+
+// solveGo returns the Go solution's answer to each part of the input, or the
+// error which stopped it (so it won't match an external solver's answer).
+func solveGo(d *aoc.Day, inputPath string) map[int]string {
+	answers := map[int]string{}
+	results, err := solveParts(context.Background(), d, d.Parts(), inputPath, nil)
+	for i, part := range d.Parts() {
+		switch {
+		case err != nil:
+			answers[part] = "error: " + err.Error()
+		case results[i].Err != nil:
+			answers[part] = "error: " + results[i].Err.Error()
+		default:
+			answers[part] = results[i].Answer.String()
+		}
+	}
+	return answers
+}
+
+// solveExternal runs an external solver on the input. A sealed input is
+// decrypted to a temporary file for it, which is removed afterwards.
+func solveExternal(s external.Solver, inputPath string, timeout time.Duration) (map[int]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if _, err := os.Stat(inputPath); errors.Is(err, os.ErrNotExist) {
+		data, err := vault.ReadFile(inputPath)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.CreateTemp("", "aoc-input-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(file.Name())
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		inputPath = file.Name()
+	}
+
+	return s.Solve(ctx, inputPath)
+}
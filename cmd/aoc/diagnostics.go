@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Andoryuuta/AdventOfCode2024/diag"
+)
+
+// errReported is returned by commands which have already reported why they
+// failed, so main only needs to set the exit code.
+var errReported = errors.New("error already reported")
+
+// reportError writes the diagnostic for errors carrying an input position, in
+// the given format ("text" or "json"), and returns errReported. Other errors
+// are returned unchanged.
+func reportError(w io.Writer, err error, format string) error {
+	d, ok := diag.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch format {
+	case "json":
+		data, jsonErr := json.Marshal(d)
+		if jsonErr != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", data)
+	default:
+		fmt.Fprint(w, d.Render())
+	}
+	return errReported
+}
+
+// validateDiagnosticsFormat checks the value of a --diagnostics flag.
+func validateDiagnosticsFormat(format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid diagnostics format %q (expected text or json)", format)
+	}
+	return nil
+}
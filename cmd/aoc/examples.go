@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/client"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/puzzle"
+)
+
+func examplesCommand(args []string) error {
+	fs := flag.NewFlagSet("examples", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory to write the dayN example files to")
+	manifestPath := fs.String("manifest", "", "expected-answer manifest (default: <data>/"+manifest.DefaultFile+")")
+	year := fs.Int("year", client.Year, "Advent of Code event year, when downloading the page")
+	list := fs.Bool("list", false, "only list the code blocks and answers found in the page")
+	block1 := fs.Int("block1", 1, "code block of part 1 which is its example input")
+	block2 := fs.Int("block2", 1, "code block of part 2 which is its example input")
+	force := fs.Bool("force", false, "overwrite examples and expected answers which differ")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return fmt.Errorf("usage: aoc examples <day> [saved puzzle page]")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+	if *manifestPath == "" {
+		*manifestPath = filepath.Join(*dataDir, manifest.DefaultFile)
+	}
+
+	var page []byte
+	if len(positional) == 2 {
+		page, err = os.ReadFile(positional[1])
+	} else {
+		page, err = fetchDescription(*year, day)
+	}
+	if err != nil {
+		return err
+	}
+
+	description, err := puzzle.Parse(string(page))
+	if err != nil {
+		return err
+	}
+	if description.Day != 0 && description.Day != day {
+		return fmt.Errorf("the page describes day %d, not day %d", description.Day, day)
+	}
+
+	if *list {
+		listExamples(description)
+		return nil
+	}
+
+	examples, err := chooseExamples(description, []int{*block1, *block2})
+	if err != nil {
+		return err
+	}
+	return writeExamples(os.Stdout, *dataDir, *manifestPath, day, examples, *force)
+}
+
+// fetchDescription downloads the puzzle description with the user's session,
+// so that part 2 is included if it has been unlocked.
+func fetchDescription(year int, day int) ([]byte, error) {
+	session, err := client.LoadSession()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(session).FetchDescription(context.Background(), year, day)
+}
+
+// listExamples prints every code block and answer found in the description.
+func listExamples(description *puzzle.Description) {
+	fmt.Printf("Day %d: %s\n", description.Day, description.Title)
+	for i, part := range description.Parts {
+		answer := part.Answer
+		if answer == "" {
+			answer = "(not found)"
+		}
+		fmt.Printf("\nPart %d, answer %s\n", i+1, answer)
+		for j, block := range part.Blocks {
+			fmt.Printf("--- block %d ---\n%s\n", j+1, block)
+		}
+	}
+}
+
+// example is an example input with the expected answer of each part.
+type example struct {
+	Name    string // file name within the day's data directory
+	Text    string
+	Answers map[int]string
+}
+
+// chooseExamples picks each part's example input from its code blocks (by
+// 1-based index). Parts with the same example share an input file.
+func chooseExamples(description *puzzle.Description, blocks []int) ([]example, error) {
+	var examples []example
+	for i, part := range description.Parts {
+		if i >= len(blocks) {
+			break
+		}
+		number := i + 1
+		if len(part.Blocks) == 0 {
+			if number == 1 {
+				return nil, errors.New("part 1 has no example")
+			}
+			// Part 2 often reuses part 1's example without repeating it.
+			examples[0].Answers[number] = part.Answer
+			continue
+		}
+		if blocks[i] < 1 || blocks[i] > len(part.Blocks) {
+			return nil, fmt.Errorf("part %d has %d code blocks, not %d", number, len(part.Blocks), blocks[i])
+		}
+
+		text := part.Blocks[blocks[i]-1]
+		if number > 1 && examples[0].Text == text {
+			examples[0].Answers[number] = part.Answer
+			continue
+		}
+		examples = append(examples, example{Text: text, Answers: map[int]string{number: part.Answer}})
+	}
+
+	// Name the files like the hand-copied examples: one shared example, or one per part.
+	for i := range examples {
+		examples[i].Name = "input_example"
+		if len(examples) > 1 {
+			examples[i].Name = fmt.Sprintf("input_example_part%d", i+1)
+		}
+	}
+	return examples, nil
+}
+
+// writeExamples writes the example files and records their answers in the
+// manifest, printing what it did to w. Nothing is written if an existing file or answer differs, unless
+// force is set. If the day is solved, each example also gets a golden file
+// (see aoctest) once its output agrees with the answers.
+func writeExamples(w io.Writer, dataDir string, manifestPath string, day int, examples []example, force bool) error {
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(dataDir, fmt.Sprintf("day%d", day))
+	var conflicts []string
+	for _, ex := range examples {
+		path := filepath.Join(dir, ex.Name)
+		if existing, err := os.ReadFile(path); err == nil && !sameExample(existing, ex.Text) {
+			conflicts = append(conflicts, fmt.Sprintf("%s has a different example", path))
+		}
+
+		key, err := manifest.Key(dataDir, path)
+		if err != nil {
+			return err
+		}
+		for part, answer := range ex.Answers {
+			if expected, ok := m.Expected(key, part); ok && answer != "" && expected != answer {
+				conflicts = append(conflicts, fmt.Sprintf("%s part %d is expected to be %s, not %s", key, part, expected, answer))
+			}
+		}
+	}
+	if len(conflicts) > 0 && !force {
+		return fmt.Errorf("%s (use --force to replace them)", strings.Join(conflicts, "; "))
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, ex := range examples {
+		path := filepath.Join(dir, ex.Name)
+		if existing, err := os.ReadFile(path); err != nil || !sameExample(existing, ex.Text) {
+			if err := os.WriteFile(path, []byte(ex.Text), 0o644); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "wrote %s\n", path)
+		}
+		key, err := manifest.Key(dataDir, path)
+		if err != nil {
+			return err
+		}
+
+		for part := 1; part <= 2; part++ {
+			answer, ok := ex.Answers[part]
+			if !ok {
+				continue
+			}
+			if answer == "" {
+				fmt.Fprintf(w, "%s: no answer found for part %d; add it to %s by hand\n", key, part, manifestPath)
+				continue
+			}
+			m.Set(key, part, answer)
+			fmt.Fprintf(w, "%s: part %d expects %s\n", key, part, answer)
+		}
+		if err := writeGolden(w, day, path, key, ex.Answers); err != nil {
+			return err
+		}
+	}
+	return m.Save(manifestPath)
+}
+
+// writeGolden writes the golden file of an example from the day's output, so
+// the golden tests cover it. It's skipped if the day isn't solved yet, or
+// gives different answers (the golden tests would fail either way).
+func writeGolden(w io.Writer, day int, path string, key string, answers map[int]string) error {
+	d, err := aoc.Lookup(day)
+	if err != nil {
+		return nil
+	}
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	output := manifest.GoldenOutput(d, input)
+	got := manifest.GoldenAnswers(output)
+	for part, answer := range answers {
+		if answer != "" && got[part] != answer {
+			fmt.Fprintf(w, "%s: day %d doesn't give part %d as %s yet; no golden file written\n", key, day, part, answer)
+			return nil
+		}
+	}
+
+	golden := path + manifest.GoldenExt
+	if existing, err := os.ReadFile(golden); err == nil && string(existing) == output {
+		return nil
+	}
+	if err := os.WriteFile(golden, []byte(output), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "wrote %s\n", golden)
+	return nil
+}
+
+// sameExample reports whether an existing example file holds the example,
+// ignoring line endings (some were saved with CRLF).
+func sameExample(existing []byte, text string) bool {
+	return strings.ReplaceAll(string(existing), "\r\n", "\n") == text
+}
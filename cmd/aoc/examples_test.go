@@ -0,0 +1,37 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+)
+
+func TestWriteExamplesGolden(t *testing.T) {
+	t.Parallel()
+	text := "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n"
+	var tests = []struct {
+		answers  map[int]string
+		expected bool
+	}{
+		{map[int]string{1: "11", 2: "31"}, true},
+		{map[int]string{1: "11", 2: ""}, true},
+		{map[int]string{1: "11", 2: "32"}, false},
+	}
+
+	for _, tt := range tests {
+		dataDir := t.TempDir()
+		manifestPath := filepath.Join(dataDir, manifest.DefaultFile)
+		examples := []example{{Name: "input_example", Text: text, Answers: tt.answers}}
+		if err := writeExamples(io.Discard, dataDir, manifestPath, 1, examples, false); err != nil {
+			t.Fatalf("got error %v, expected nil", err)
+		}
+
+		_, err := os.Stat(filepath.Join(dataDir, "day1", "input_example"+manifest.GoldenExt))
+		if got := err == nil; got != tt.expected {
+			t.Errorf("got golden file written %v for answers %v, expected %v", got, tt.answers, tt.expected)
+		}
+	}
+}
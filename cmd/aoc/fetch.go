@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/client"
+)
+
+func fetchCommand(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory to store the dayN/input files in")
+	year := fs.Int("year", client.Year, "Advent of Code event year")
+	baseURL := fs.String("url", client.DefaultBaseURL, "Advent of Code website URL")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: aoc fetch <day|all>")
+	}
+
+	var days []int
+	if isAll(positional[0]) {
+		days = aoc.Days()
+	} else {
+		day, err := strconv.Atoi(positional[0])
+		if err != nil || day < 1 || day > 25 {
+			return fmt.Errorf("invalid day %q", positional[0])
+		}
+		days = []int{day}
+	}
+
+	session, err := client.LoadSession()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+	c.BaseURL = *baseURL
+	cache := &client.InputCache{Dir: *dataDir}
+
+	for _, day := range days {
+		path, fetched, err := c.FetchCachedInput(context.Background(), cache, *year, day)
+		if err != nil {
+			return err
+		}
+		if fetched {
+			fmt.Printf("Day %d: fetched %s\n", day, path)
+		} else {
+			fmt.Printf("Day %d: already cached at %s\n", day, path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Andoryuuta/AdventOfCode2024/gen"
+)
+
+func genCommand(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	seed := fs.Int64("seed", 1, "random seed; the same seed and size always generate the same input")
+	size := fs.Int("size", 0, "size of the input (default: about the size of a real input)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return fmt.Errorf("usage: aoc gen <day> [--seed N] [--size M] [output file]")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+
+	g, err := gen.Lookup(day)
+	if err != nil {
+		return err
+	}
+	if *size == 0 {
+		*size = g.DefaultSize
+	}
+	input, err := g.Generate(*seed, *size)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 1 {
+		_, err = os.Stdout.Write(input)
+		return err
+	}
+	if err := os.WriteFile(positional[1], input, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "generated %s: day %d, seed %d, %d %s\n", positional[1], day, *seed, *size, g.Unit)
+	return nil
+}
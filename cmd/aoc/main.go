@@ -0,0 +1,121 @@
+// Command aoc is the single entry point for running the Advent of Code 2024
+// solutions registered with the aoc package.
+//
+// Usage:
+//
+//	aoc run <day|all> [--part N] [--format json] [--memory 512MiB] [input file]
+//	aoc fetch <day|all>
+//	aoc submit <day> <part> [input file]
+//	aoc verify [day|all]
+//	aoc bench [day|all] [--iterations N]
+//	aoc new <day> [--title T]
+//	aoc watch <day>
+//	aoc serve [--addr host:port]
+//	aoc cache <list|clear>
+//	aoc vault <seal|open> <day|all>
+//	aoc examples <day> [saved puzzle page]
+//	aoc gen <day> [--seed N] [--size M] [output file]
+//	aoc compare [day|all] [--config file]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	_ "github.com/Andoryuuta/AdventOfCode2024/days"
+)
+
+const (
+	// defaultDataDir is where puzzle inputs live, relative to the repository root.
+	defaultDataDir = "challenge_data"
+
+	// defaultStateDir holds local, untracked state such as the submission history.
+	defaultStateDir = ".aoc"
+
+	// defaultCacheDir holds the cached answers (see the cache package).
+	defaultCacheDir = defaultStateDir + "/cache"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"run", "run <day|all> [--part N] [--data dir] [--timeout 30s] [--jobs N|--sequential] [--no-cache] [--memory size] [--format text|json] [--diagnostics text|json] [input file]", runCommand},
+	{"fetch", "fetch <day|all> [--data dir] [--year N]", fetchCommand},
+	{"submit", "submit <day> <part> [--answer X] [--data dir] [input file]", submitCommand},
+	{"verify", "verify [day|all] [--data dir] [--manifest file]", verifyCommand},
+	{"bench", "bench [day|all] [--iterations N] [--no-history] [--threshold 0.1] [--fail-on-regression]", benchCommand},
+	{"new", "new <day> [--title T] [--data dir]", newCommand},
+	{"watch", "watch <day> [--interval 500ms] [--data dir] [--manifest file]", watchCommand},
+	{"serve", "serve [--addr host:port] [--max-input bytes] [--timeout 30s] [--max-solves N]", serveCommand},
+	{"cache", "cache <list|clear> [--stale] [--cache dir]", cacheCommand},
+	{"vault", "vault <seal|open> <day|all> [--force] [--data dir]", vaultCommand},
+	{"examples", "examples <day> [saved puzzle page] [--list] [--block1 N] [--block2 N] [--force]", examplesCommand},
+	{"gen", "gen <day> [--seed N] [--size M] [output file]", genCommand},
+	{"compare", "compare [day|all] [--config file] [--data dir] [--timeout 1m]", compareCommand},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: aoc <command> [arguments]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", cmd.usage)
+	}
+}
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	for _, cmd := range commands {
+		if cmd.name == name {
+			if err := cmd.run(os.Args[2:]); errors.Is(err, errReported) {
+				os.Exit(1)
+			} else if err != nil {
+				log.Fatalf("aoc %s: %v", name, err)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+// parseArgs parses flags that may be interleaved with positional arguments
+// (e.g. `aoc run 6 --part 2 input.txt`) and returns the positional arguments.
+// The standard flag package stops at the first non-flag argument.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// isAll reports whether a day argument selects every registered day.
+func isAll(arg string) bool {
+	return strings.EqualFold(arg, "all")
+}
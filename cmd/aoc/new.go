@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Andoryuuta/AdventOfCode2024/scaffold"
+)
+
+func newCommand(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	root := fs.String("root", ".", "repository root, holding go.mod")
+	dataDir := fs.String("data", defaultDataDir, "data directory, relative to the repository root")
+	title := fs.String("title", "", "puzzle title, for the package documentation")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: aoc new <day> [--title T]")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+
+	created, err := scaffold.Generate(scaffold.Options{Root: *root, DataDir: *dataDir, Day: day, Title: *title})
+	for _, path := range created {
+		fmt.Printf("created %s\n", path)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("registered day %d in days/days.go\n", day)
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// answerRecord is the stable JSON schema for a solved part, written one
+// object per line by `aoc run --format json`.
+type answerRecord struct {
+	Day         int            `json:"day"`
+	Part        int            `json:"part"`
+	Answer      string         `json:"answer,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	DurationNS  int64          `json:"duration_ns"`
+	Input       string         `json:"input"`
+	InputSHA256 string         `json:"input_sha256"`
+	Meta        map[string]any `json:"meta,omitempty"`
+	Cached      bool           `json:"cached,omitempty"`
+}
+
+// newAnswerRecord converts the result of solving a part to its JSON record.
+func newAnswerRecord(day int, inputPath string, result partResult) answerRecord {
+	record := answerRecord{
+		Day:         day,
+		Part:        result.Part,
+		DurationNS:  result.Duration.Nanoseconds(),
+		Input:       inputPath,
+		InputSHA256: result.InputSHA256,
+		Cached:      result.Cached,
+	}
+	if result.Err != nil {
+		record.Error = result.Err.Error()
+	} else {
+		record.Answer = result.Answer.String()
+		record.Meta = result.Answer.Meta
+	}
+	return record
+}
+
+// writeAnswer writes the result of solving a part in the given format
+// ("text" or "json").
+func writeAnswer(w io.Writer, format string, day int, inputPath string, result partResult) error {
+	if format == "json" {
+		data, err := json.Marshal(newAnswerRecord(day, inputPath, result))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	if result.Err != nil {
+		return nil
+	}
+	var cached string
+	if result.Cached {
+		cached = " [cached]"
+	}
+	var meta string
+	if details := result.Answer.MetaString(); details != "" {
+		meta = " " + details
+	}
+	_, err := fmt.Fprintf(w, "Day %d, part %d: %v%s%s\n", day, result.Part, result.Answer, meta, cached)
+	return err
+}
+
+// validateOutputFormat checks the value of a --format flag.
+func validateOutputFormat(format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid output format %q (expected text or json)", format)
+	}
+	return nil
+}
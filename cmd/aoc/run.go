@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/cache"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "only run the given part (1 or 2)")
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN/input files")
+	diagnostics := fs.String("diagnostics", "text", "format of input diagnostics: text or json")
+	format := fs.String("format", "text", "format of the answers: text or json")
+	timeout := fs.Duration("timeout", 0, "time allowed for each day, e.g. 30s (0 for no limit)")
+	jobs := fs.Int("jobs", runtime.GOMAXPROCS(0), "number of days to run at once when running all days")
+	sequential := fs.Bool("sequential", false, "run all days one at a time (for accurate timings); same as --jobs 1")
+	noCache := fs.Bool("no-cache", false, "solve every part, instead of using cached answers")
+	cacheDir := fs.String("cache", defaultCacheDir, "directory of cached answers")
+	var memory byteSize
+	fs.Var(&memory, "memory", "stream the input using about `size` bytes of memory (e.g. 512MiB), for inputs too large to parse (days with a streaming mode only)")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := validateDiagnosticsFormat(*diagnostics); err != nil {
+		return err
+	}
+	if err := validateOutputFormat(*format); err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return fmt.Errorf("usage: aoc run <day|all> [--part N] [input file]")
+	}
+
+	// Interrupting a run stops the current part, which reports its progress.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var answers *cache.Cache
+	if !*noCache {
+		answers = &cache.Cache{Dir: *cacheDir}
+	}
+
+	if isAll(positional[0]) {
+		if len(positional) != 1 {
+			return fmt.Errorf("an input file cannot be given when running all days")
+		}
+		if memory > 0 {
+			return fmt.Errorf("--memory cannot be used when running all days")
+		}
+
+		if *sequential {
+			*jobs = 1
+		}
+		return runAll(ctx, os.Stdout, os.Stderr, runAllOptions{
+			DataDir:     *dataDir,
+			Part:        *part,
+			Jobs:        *jobs,
+			Timeout:     *timeout,
+			Format:      *format,
+			Diagnostics: *diagnostics,
+			Cache:       answers,
+		})
+	}
+
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+
+	inputPath := defaultInputPath(*dataDir, day)
+	if len(positional) == 2 {
+		inputPath = positional[1]
+	}
+
+	if memory > 0 {
+		err = streamDay(ctx, day, *part, inputPath, *format, *timeout, int64(memory))
+	} else {
+		err = runDay(ctx, os.Stdout, day, *part, inputPath, *format, *timeout, answers)
+	}
+	if err != nil {
+		return reportError(os.Stderr, err, *diagnostics)
+	}
+	return nil
+}
+
+// defaultInputPath returns the location of a day's personal puzzle input.
+func defaultInputPath(dataDir string, day int) string {
+	return filepath.Join(dataDir, fmt.Sprintf("day%d", day), "input")
+}
+
+// runDay runs either the given part, or every part when part is 0, and
+// prints the answers to w in the given format: every part's, even if an
+// earlier part failed. A timeout of 0 means no limit, and a nil cache
+// disables caching.
+func runDay(ctx context.Context, w io.Writer, day int, part int, inputPath string, format string, timeout time.Duration, answers *cache.Cache) error {
+	results, err := solveDay(ctx, day, part, inputPath, timeout, answers)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := writeAnswer(w, format, day, inputPath, result); err != nil {
+			return err
+		}
+	}
+	return partErrors(results)
+}
+
+// partErrors returns an error listing every part which failed, or nil if
+// none did. Each part's error is wrapped, so parse errors are still reported
+// as diagnostics.
+func partErrors(results []partResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("part %d: %w", result.Part, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// solveDay solves either the given part, or every part when part is 0,
+// within the timeout (or without a limit, if it is 0). Answers are taken
+// from and stored in the cache, unless it is nil.
+func solveDay(ctx context.Context, day int, part int, inputPath string, timeout time.Duration, answers *cache.Cache) ([]partResult, error) {
+	d, err := aoc.Lookup(day)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := d.Parts()
+	if part != 0 {
+		parts = []int{part}
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return solveParts(ctx, d, parts, inputPath, answers)
+}
+
+// partResult is the outcome of solving a single part of a day.
+type partResult struct {
+	Part        int
+	Answer      aoc.Answer
+	Err         error
+	Duration    time.Duration // time taken to solve the part, excluding parsing
+	InputSHA256 string        // hex-encoded SHA-256 of the input file
+	Cached      bool          // the answer (and duration) came from the cache
+}
+
+// solveParts parses the input file once, then solves each of the given parts.
+// An error is only returned if the input cannot be read or parsed.
+//
+// If answers is not nil, parts with a cached answer aren't solved again (and
+// the input isn't parsed at all if every part is cached), and new answers
+// are added to the cache.
+func solveParts(ctx context.Context, d *aoc.Day, parts []int, inputPath string, answers *cache.Cache) ([]partResult, error) {
+	data, err := vault.ReadFile(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open input file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	inputHash := hex.EncodeToString(sum[:])
+
+	var version string
+	if answers != nil {
+		if version, err = cache.SolverVersion(); err != nil {
+			return nil, fmt.Errorf("cannot identify the solver version for the cache: %v", err)
+		}
+	}
+
+	var input any
+	parsed := false
+	var results []partResult
+	for _, part := range parts {
+		if answers != nil {
+			if result, ok := cachedResult(answers, d.Number, part, inputHash, version); ok {
+				results = append(results, result)
+				continue
+			}
+		}
+
+		if !parsed {
+			if input, err = d.Parse(namedReader{bytes.NewReader(data), inputPath}); err != nil {
+				return nil, err
+			}
+			parsed = true
+		}
+
+		start := time.Now()
+		answer, err := d.Part(ctx, part, input)
+		result := partResult{Part: part, Answer: answer, Err: err, Duration: time.Since(start), InputSHA256: inputHash}
+		results = append(results, result)
+
+		if answers != nil && err == nil {
+			// The cache only saves time, so failing to update it isn't an error.
+			answers.Put(cache.Entry{
+				Day:           d.Number,
+				Part:          part,
+				InputSHA256:   inputHash,
+				SolverVersion: version,
+				Answer:        answer.String(),
+				Meta:          answer.Meta,
+				DurationNS:    result.Duration.Nanoseconds(),
+				CreatedAt:     time.Now(),
+			})
+		}
+	}
+	return results, nil
+}
+
+// cachedResult returns the result of a part from the cache, if it is there.
+func cachedResult(answers *cache.Cache, day int, part int, inputHash string, version string) (partResult, bool) {
+	entry, ok, err := answers.Get(day, part, inputHash, version)
+	if err != nil || !ok {
+		return partResult{}, false
+	}
+	value, err := strconv.ParseInt(entry.Answer, 10, 64)
+	if err != nil {
+		return partResult{}, false
+	}
+
+	return partResult{
+		Part:        part,
+		Answer:      aoc.Answer{Value: value, Meta: entry.Meta},
+		Duration:    time.Duration(entry.DurationNS),
+		InputSHA256: inputHash,
+		Cached:      true,
+	}, true
+}
+
+// namedReader is an in-memory input which keeps the name of the file it was
+// read from, so parse errors still report it (see parse.NameOf).
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (r namedReader) Name() string {
+	return r.name
+}
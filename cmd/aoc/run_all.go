@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/cache"
+)
+
+// runAllOptions are the options of `aoc run all`.
+type runAllOptions struct {
+	DataDir     string
+	Part        int // 0 for every part
+	Jobs        int // number of days solved at once
+	Timeout     time.Duration
+	Format      string       // output format of the answers
+	Diagnostics string       // format of input diagnostics
+	Cache       *cache.Cache // nil to disable caching
+}
+
+// dayResult is the outcome of solving a day's input.
+type dayResult struct {
+	Day       int
+	InputPath string
+	Parts     []partResult
+	Err       error // the input couldn't be read or parsed
+}
+
+// runAll solves every registered day from its personal input, using a pool of
+// opts.Jobs workers, then prints the answers to stdout in day order: as a
+// summary table for text output, or as answer records for JSON output.
+// Errors are printed to stderr.
+func runAll(ctx context.Context, stdout io.Writer, stderr io.Writer, opts runAllOptions) error {
+	start := time.Now()
+	results := solveAll(ctx, aoc.Days(), opts)
+	elapsed := time.Since(start)
+
+	if opts.Format == "json" {
+		for _, result := range results {
+			for _, part := range result.Parts {
+				if err := writeAnswer(stdout, opts.Format, result.Day, result.InputPath, part); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		writeSummary(stdout, results, opts.Jobs, elapsed)
+	}
+
+	// Errors are reported after the answers, so the table isn't broken up.
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, fs.ErrNotExist) {
+			if reportError(stderr, result.Err, opts.Diagnostics) != errReported {
+				fmt.Fprintf(stderr, "Day %d: %v\n", result.Day, result.Err)
+			}
+		}
+		for _, part := range result.Parts {
+			if part.Err != nil {
+				fmt.Fprintf(stderr, "Day %d, part %d: %v\n", result.Day, part.Part, part.Err)
+			}
+		}
+	}
+
+	switch days, parts := countFailures(results); {
+	case days > 0 && parts > 0:
+		return fmt.Errorf("%s and %s failed", plural(days, "day"), plural(parts, "part"))
+	case days > 0:
+		return fmt.Errorf("%s failed", plural(days, "day"))
+	case parts > 0:
+		return fmt.Errorf("%s failed", plural(parts, "part"))
+	}
+	return nil
+}
+
+// countFailures counts the days whose input couldn't be read or parsed, and
+// the parts which failed. A day without an input isn't a failure: on a fresh
+// checkout, most days have none.
+func countFailures(results []dayResult) (days int, parts int) {
+	for _, result := range results {
+		if result.Err != nil {
+			if !errors.Is(result.Err, fs.ErrNotExist) {
+				days++
+			}
+			continue
+		}
+		for _, part := range result.Parts {
+			if part.Err != nil {
+				parts++
+			}
+		}
+	}
+	return days, parts
+}
+
+// plural returns the count followed by the noun, pluralised unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// solveAll solves the days with a pool of opts.Jobs workers, and returns the
+// results in the same order as days.
+func solveAll(ctx context.Context, days []int, opts runAllOptions) []dayResult {
+	results := make([]dayResult, len(days))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < max(opts.Jobs, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				day := days[i]
+				inputPath := defaultInputPath(opts.DataDir, day)
+				parts, err := solveDay(ctx, day, opts.Part, inputPath, opts.Timeout, opts.Cache)
+				results[i] = dayResult{Day: day, InputPath: inputPath, Parts: parts, Err: err}
+			}
+		}()
+	}
+
+	for i := range days {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// writeSummary prints a table of every part's answer, time and status.
+func writeSummary(out io.Writer, results []dayResult, jobs int, elapsed time.Duration) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tPART\tANSWER\tTIME\tSTATUS")
+
+	parts := 0
+	for _, result := range results {
+		if result.Err != nil {
+			status := "error"
+			if errors.Is(result.Err, fs.ErrNotExist) {
+				status = "no input"
+			}
+			fmt.Fprintf(w, "%d\t-\t-\t-\t%s\n", result.Day, status)
+			continue
+		}
+
+		for _, part := range result.Parts {
+			parts++
+			answer, status := part.Answer.String(), "ok"
+			switch {
+			case errors.Is(part.Err, context.DeadlineExceeded):
+				answer, status = "-", "timeout"
+			case errors.Is(part.Err, context.Canceled):
+				answer, status = "-", "interrupted"
+			case part.Err != nil:
+				answer, status = "-", "error"
+			case part.Cached:
+				status = "cached"
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%v\t%s\n", result.Day, part.Part, answer, part.Duration.Round(time.Microsecond), status)
+		}
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\n%d parts of %d days in %v (%d at once)\n", parts, len(results), elapsed.Round(time.Microsecond), max(jobs, 1))
+}
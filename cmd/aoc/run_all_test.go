@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAllMissingInputs(t *testing.T) {
+	t.Parallel()
+	var stderr bytes.Buffer
+	defer func() {
+		if t.Failed() {
+			t.Logf("errors:\n%s", stderr.String())
+		}
+	}()
+
+	dataDir := t.TempDir()
+	opts := runAllOptions{DataDir: dataDir, Jobs: 2, Format: "text", Diagnostics: "text"}
+
+	// A fresh checkout, with no inputs at all, runs successfully.
+	if err := runAll(context.Background(), io.Discard, &stderr, opts); err != nil {
+		t.Errorf("got error %v, expected nil", err)
+	}
+
+	// A malformed input still fails.
+	if err := os.MkdirAll(filepath.Join(dataDir, "day1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(defaultInputPath(dataDir, 1), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAll(context.Background(), io.Discard, &stderr, opts); err == nil || !strings.Contains(err.Error(), "1 day failed") {
+		t.Errorf("got error %v, expected 1 day to fail", err)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	var tests = []struct {
+		n        int
+		expected string
+	}{
+		{0, "0 parts"},
+		{1, "1 part"},
+		{2, "2 parts"},
+	}
+
+	for _, tt := range tests {
+		if got := plural(tt.n, "part"); got != tt.expected {
+			t.Errorf("got %v, expected %v", got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+)
+
+// failingSolver fails part 1, and solves part 2 with the length of the input.
+type failingSolver struct{}
+
+func (failingSolver) Parse(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+func (failingSolver) Part1(ctx context.Context, input []byte) (aoc.Answer, error) {
+	return aoc.Answer{}, errors.New("no solution")
+}
+
+func (failingSolver) Part2(ctx context.Context, input []byte) (aoc.Answer, error) {
+	return aoc.IntAnswer(len(input)), nil
+}
+
+func init() {
+	aoc.Register[[]byte](24, failingSolver{})
+}
+
+func TestRunDayFailedPart(t *testing.T) {
+	t.Parallel()
+	inputPath := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(inputPath, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		format   string
+		expected []string
+	}{
+		{"text", []string{"Day 24, part 2: 3"}},
+		{"json", []string{`"part":1`, `"part":2`}},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := runDay(context.Background(), &out, 24, 0, inputPath, tt.format, 0, nil)
+		if err == nil || !strings.Contains(err.Error(), "part 1: no solution") {
+			t.Errorf("got error %v, expected part 1 to fail", err)
+		}
+		// Part 2 is still printed after part 1 fails.
+		for _, expected := range tt.expected {
+			if !strings.Contains(out.String(), expected) {
+				t.Errorf("got output %q, expected it to contain %q", out.String(), expected)
+			}
+		}
+	}
+}
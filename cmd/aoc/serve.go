@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/server"
+)
+
+func serveCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	maxInput := fs.Int64("max-input", server.DefaultMaxInputSize, "maximum size of an input, in bytes")
+	timeout := fs.Duration("timeout", server.DefaultTimeout, "time allowed to solve each request")
+	maxSolves := fs.Int("max-solves", runtime.NumCPU(), "number of inputs solved at once")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("usage: aoc serve [--addr host:port]")
+	}
+
+	s := server.New()
+	s.MaxInputSize = *maxInput
+	s.Timeout = *timeout
+	s.MaxSolves = *maxSolves
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("listening on http://%s", *addr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
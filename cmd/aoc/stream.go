@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+// streamDay runs either the given part, or every part when part is 0, by
+// streaming the input file with about memoryLimit bytes of memory (see
+// aoc.StreamSolver), and prints the answers in the given format. The input
+// is read again for each part, and answers aren't cached: hashing a huge
+// input would take as long as streaming it.
+func streamDay(ctx context.Context, day int, part int, inputPath string, format string, timeout time.Duration, memoryLimit int64) error {
+	d, err := aoc.Lookup(day)
+	if err != nil {
+		return err
+	}
+	if !d.CanStream() {
+		return fmt.Errorf("day %d has no streaming mode (run it without --memory)", day)
+	}
+
+	parts := d.Parts()
+	if part != 0 {
+		parts = []int{part}
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var results []partResult
+	for _, part := range parts {
+		result, err := streamPart(ctx, d, part, inputPath, memoryLimit)
+		if err != nil {
+			return err
+		}
+		if err := writeAnswer(os.Stdout, format, day, inputPath, result); err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+	return partErrors(results)
+}
+
+// streamPart solves a part by streaming the input file. An error is only
+// returned if the file can't be opened.
+func streamPart(ctx context.Context, d *aoc.Day, part int, inputPath string, memoryLimit int64) (partResult, error) {
+	f, err := os.Open(inputPath)
+	if errors.Is(err, os.ErrNotExist) {
+		if _, statErr := os.Stat(inputPath + vault.Ext); statErr == nil {
+			return partResult{}, fmt.Errorf("cannot stream sealed input file %s (open it with aoc vault first)", inputPath+vault.Ext)
+		}
+	}
+	if err != nil {
+		return partResult{}, fmt.Errorf("cannot open input file: %w", err)
+	}
+	defer f.Close()
+
+	start := time.Now()
+	answer, err := d.SolveStream(ctx, part, f, memoryLimit)
+	return partResult{Part: part, Answer: answer, Err: err, Duration: time.Since(start)}, nil
+}
+
+// byteSize is a flag holding a number of bytes, such as "512MiB" or "2GB".
+type byteSize int64
+
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9},
+	{"B", 1},
+}
+
+func (b *byteSize) String() string {
+	return strconv.FormatInt(int64(*b), 10)
+}
+
+func (b *byteSize) Set(s string) error {
+	number, unit := s, int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			number, unit = strings.TrimSuffix(s, u.suffix), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n < 0 || n > (1<<63-1)/unit {
+		return fmt.Errorf("invalid size %q (expected e.g. 512MiB)", s)
+	}
+	*b = byteSize(n * unit)
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/client"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+func submitCommand(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN/input files")
+	year := fs.Int("year", client.Year, "Advent of Code event year")
+	baseURL := fs.String("url", client.DefaultBaseURL, "Advent of Code website URL")
+	answerOverride := fs.String("answer", "", "submit this answer instead of running the solver")
+	historyPath := fs.String("history", filepath.Join(defaultStateDir, "submissions.jsonl"), "submission history file")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 || len(positional) > 3 {
+		return fmt.Errorf("usage: aoc submit <day> <part> [input file]")
+	}
+
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+	part, err := strconv.Atoi(positional[1])
+	if err != nil || part < 1 || part > 2 {
+		return fmt.Errorf("invalid part %q", positional[1])
+	}
+
+	answer := *answerOverride
+	if answer == "" {
+		inputPath := defaultInputPath(*dataDir, day)
+		if len(positional) == 3 {
+			inputPath = positional[2]
+		}
+
+		solved, err := solveFile(day, part, inputPath)
+		if err != nil {
+			return err
+		}
+		answer = solved.String()
+	}
+
+	session, err := client.LoadSession()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+	c.BaseURL = *baseURL
+
+	fmt.Printf("Submitting day %d, part %d answer: %s\n", day, part, answer)
+	result, err := c.SubmitRecorded(context.Background(), &client.History{Path: *historyPath}, *year, day, part, answer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", result.Outcome, result.Message)
+	if result.Outcome != client.OutcomeCorrect {
+		return errReported
+	}
+	return nil
+}
+
+// solveFile solves a single part of a day from an input file.
+func solveFile(day int, part int, inputPath string) (aoc.Answer, error) {
+	d, err := aoc.Lookup(day)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+
+	data, err := vault.ReadFile(inputPath)
+	if err != nil {
+		return aoc.Answer{}, fmt.Errorf("cannot open input file: %v", err)
+	}
+
+	return d.Solve(context.Background(), part, namedReader{bytes.NewReader(data), inputPath})
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+func vaultCommand(args []string) error {
+	fs := flag.NewFlagSet("vault", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN/input files")
+	force := fs.Bool("force", false, "overwrite files whose content differs")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 || (positional[0] != "seal" && positional[0] != "open") {
+		return fmt.Errorf("usage: aoc vault <seal|open> <day|all> [--force]")
+	}
+	seal := positional[0] == "seal"
+
+	var days []int
+	if isAll(positional[1]) {
+		for day := 1; day <= 25; day++ {
+			days = append(days, day)
+		}
+	} else {
+		day, err := strconv.Atoi(positional[1])
+		if err != nil || day < 1 || day > 25 {
+			return fmt.Errorf("invalid day %q", positional[1])
+		}
+		days = []int{day}
+	}
+
+	passphrase, err := vault.LoadPassphrase()
+	if err != nil {
+		return err
+	}
+
+	for _, day := range days {
+		inputPath := defaultInputPath(*dataDir, day)
+		from, to := inputPath, inputPath+vault.Ext
+		if !seal {
+			from, to = to, from
+		}
+
+		if _, err := os.Stat(from); errors.Is(err, os.ErrNotExist) && len(days) > 1 {
+			// When sealing or opening every day, days without inputs are skipped.
+			continue
+		}
+
+		status, err := vaultFile(from, to, seal, passphrase, *force)
+		if err != nil {
+			return fmt.Errorf("day %d: %v", day, err)
+		}
+		fmt.Printf("Day %d: %s %s\n", day, status, to)
+	}
+	return nil
+}
+
+// vaultFile seals (or opens) the file from into the file to, and describes
+// what was done. An existing file is only replaced if force is set, unless it
+// already has the same content.
+func vaultFile(from string, to string, seal bool, passphrase string, force bool) (string, error) {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext := data
+	if !seal {
+		if plaintext, err = vault.Open(data, passphrase); err != nil {
+			return "", fmt.Errorf("%s: %w", from, err)
+		}
+	}
+
+	if existing, err := os.ReadFile(to); err == nil {
+		// Sealing is randomised, so compare the plaintexts rather than the files.
+		existingPlaintext := existing
+		if seal {
+			existingPlaintext, _ = vault.Open(existing, passphrase)
+		}
+		if bytes.Equal(existingPlaintext, plaintext) && existingPlaintext != nil {
+			return "unchanged", nil
+		}
+		if !force {
+			return "", fmt.Errorf("%s already exists with different content (use --force to replace it)", to)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	output := plaintext
+	if seal {
+		if output, err = vault.Seal(plaintext, passphrase); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(to, output, 0o644); err != nil {
+		return "", err
+	}
+
+	if seal {
+		return "sealed", nil
+	}
+	return "opened", nil
+}
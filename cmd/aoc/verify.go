@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+)
+
+func verifyCommand(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN input files")
+	manifestPath := fs.String("manifest", "", "expected-answer manifest (default: <data>/"+manifest.DefaultFile+")")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("usage: aoc verify [day|all]")
+	}
+
+	days := aoc.Days()
+	if len(positional) == 1 && !isAll(positional[0]) {
+		day, err := strconv.Atoi(positional[0])
+		if err != nil {
+			return fmt.Errorf("invalid day %q", positional[0])
+		}
+		days = []int{day}
+	}
+
+	if *manifestPath == "" {
+		*manifestPath = filepath.Join(*dataDir, manifest.DefaultFile)
+	}
+	m, err := manifest.Load(*manifestPath)
+	if err != nil {
+		return err
+	}
+
+	counts := map[manifest.Status]int{}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tPART\tINPUT\tANSWER\tEXPECTED\tSTATUS")
+	for _, day := range days {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			return err
+		}
+
+		inputs, err := manifest.InputFiles(*dataDir, day)
+		if err != nil {
+			return err
+		}
+		for _, inputPath := range inputs {
+			key, err := manifest.Key(*dataDir, inputPath)
+			if err != nil {
+				return err
+			}
+
+			results, parseErr := solveParts(context.Background(), d, d.Parts(), inputPath, nil)
+			for i, part := range d.Parts() {
+				expected, ok := m.Expected(key, part)
+				if !ok {
+					expected = "-"
+				}
+
+				var answer string
+				var status manifest.Status
+				if parseErr != nil {
+					answer, status = parseErr.Error(), manifest.StatusError
+				} else if result := results[i]; result.Err != nil {
+					answer, status = result.Err.Error(), manifest.StatusError
+				} else {
+					answer = result.Answer.String()
+					status = m.Check(key, part, answer)
+				}
+
+				counts[status]++
+				fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\n", day, part, key, answer, expected, status)
+			}
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d passed, %d failed, %d errors, %d missing\n",
+		counts[manifest.StatusPass], counts[manifest.StatusFail], counts[manifest.StatusError], counts[manifest.StatusMissing])
+	if counts[manifest.StatusFail] > 0 || counts[manifest.StatusError] > 0 {
+		return fmt.Errorf("%d regressions", counts[manifest.StatusFail]+counts[manifest.StatusError])
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/watch"
+)
+
+func watchCommand(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	dataDir := fs.String("data", defaultDataDir, "directory holding the dayN input files")
+	manifestPath := fs.String("manifest", "", "expected-answer manifest (default: <data>/"+manifest.DefaultFile+")")
+	interval := fs.Duration("interval", watch.DefaultInterval, "time between checks for changes")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: aoc watch <day>")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+
+	// Everything is resolved from the module root, so watch works from any
+	// directory in the repository.
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	if *dataDir, err = resolveFlagPath(fs, "data", root); err != nil {
+		return err
+	}
+	if *manifestPath == "" {
+		*manifestPath = filepath.Join(*dataDir, manifest.DefaultFile)
+	}
+
+	// The day's code is rebuilt into a fresh binary on every change, so the
+	// answers always come from the code as it is on disk.
+	buildDir, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+	binary := filepath.Join(buildDir, "aoc")
+
+	patterns, err := watchPatterns(root, day, *dataDir, *manifestPath)
+	if err != nil {
+		return err
+	}
+	w := watch.New(patterns...)
+	w.Interval = *interval
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Watching day %d (interrupt to stop)\n", day)
+	previous := map[string]string{}
+	for first := true; ; first = false {
+		changed, err := w.Wait(ctx)
+		if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		reason := describeChanges(root, changed)
+		if first {
+			reason = "initial run"
+		}
+		fmt.Printf("\n[%s] %s\n", time.Now().Format("15:04:05"), reason)
+		if err := watchRun(ctx, root, binary, day, *dataDir, *manifestPath, previous); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		// The change may have added or removed a dependency.
+		if patterns, err := watchPatterns(root, day, *dataDir, *manifestPath); err == nil {
+			w.Patterns = patterns
+		}
+	}
+}
+
+// resolveFlagPath returns the absolute path given by a flag: relative to the
+// current directory if it was set, or to the module root for its default.
+func resolveFlagPath(fs *flag.FlagSet, name string, root string) (string, error) {
+	value := fs.Lookup(name).Value.String()
+	set := false
+	fs.Visit(func(f *flag.Flag) { set = set || f.Name == name })
+	if !set && !filepath.IsAbs(value) {
+		return filepath.Join(root, value), nil
+	}
+	return filepath.Abs(value)
+}
+
+// moduleRoot returns the directory of the repository's Go module.
+func moduleRoot() (string, error) {
+	output, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}").Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot find the module root (run aoc watch inside the repository): %v", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// watchPatterns returns the patterns of the files whose changes can affect
+// the day's answers: the Go files of every package in the module which the
+// day or the runner depends on (except the other days), the day's inputs and
+// the manifest.
+func watchPatterns(root string, day int, dataDir string, manifestPath string) ([]string, error) {
+	dayPackage := fmt.Sprintf("./days/day%d", day)
+	list := exec.Command("go", "list", "-e", "-deps", "-f", "{{if and .Module .Module.Main}}{{.ImportPath}}\t{{.Dir}}{{end}}", dayPackage, "./cmd/aoc")
+	list.Dir = root
+	var stderr bytes.Buffer
+	list.Stderr = &stderr
+	output, err := list.Output()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the packages of day %d: %v\n%s", day, err, stderr.String())
+	}
+
+	patterns := []string{filepath.Join(dataDir, fmt.Sprintf("day%d", day), "*"), manifestPath}
+	dayImportSuffix := fmt.Sprintf("/days/day%d", day)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		importPath, dir, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		if strings.Contains(importPath, "/days/day") && !strings.HasSuffix(importPath, dayImportSuffix) {
+			// The runner imports every day, but only this one's answers are shown.
+			continue
+		}
+		patterns = append(patterns, filepath.Join(dir, "*.go"))
+	}
+	return patterns, nil
+}
+
+// describeChanges summarises the files which triggered a run, relative to
+// the module root.
+func describeChanges(root string, changed []string) string {
+	const maxListed = 3
+	for i, file := range changed {
+		if rel, err := filepath.Rel(root, file); err == nil {
+			changed[i] = rel
+		}
+	}
+	if len(changed) > maxListed {
+		return fmt.Sprintf("%s and %d more changed", strings.Join(changed[:maxListed], ", "), len(changed)-maxListed)
+	}
+	return strings.Join(changed, ", ") + " changed"
+}
+
+// watchRun rebuilds the runner, runs the day against every input, and prints
+// each answer next to its previous value and the expected answer. previous
+// is updated with the new answers.
+func watchRun(ctx context.Context, root string, binary string, day int, dataDir string, manifestPath string, previous map[string]string) error {
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, "./cmd/aoc")
+	build.Dir = root
+	if output, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("build failed: %v\n%s", err, output)
+	}
+
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+	inputs, err := manifest.InputFiles(dataDir, day)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("no inputs in %s", filepath.Join(dataDir, fmt.Sprintf("day%d", day)))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "INPUT\tPART\tANSWER\tPREVIOUS\tEXPECTED\tSTATUS\tTIME")
+	for _, inputPath := range inputs {
+		key, err := manifest.Key(dataDir, inputPath)
+		if err != nil {
+			return err
+		}
+
+		var stderr bytes.Buffer
+		run := exec.CommandContext(ctx, binary, "run", strconv.Itoa(day), "--format", "json", "--no-cache", inputPath)
+		run.Stderr = &stderr
+		stdout, _ := run.Output()
+
+		records := 0
+		scanner := bufio.NewScanner(bytes.NewReader(stdout))
+		for scanner.Scan() {
+			var record answerRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				continue
+			}
+			records++
+
+			answer, status := record.Answer, manifest.StatusError
+			if record.Error != "" {
+				answer = record.Error
+			} else {
+				status = m.Check(key, record.Part, answer)
+			}
+
+			expected, ok := m.Expected(key, record.Part)
+			if !ok {
+				expected = "-"
+			}
+
+			id := fmt.Sprintf("%s/%d", key, record.Part)
+			was, seen := previous[id]
+			switch {
+			case !seen:
+				was = "-"
+			case was == answer:
+				was = "="
+			}
+			previous[id] = answer
+
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%v\n", key, record.Part, answer, was, expected, status, time.Duration(record.DurationNS))
+		}
+
+		if records == 0 || stderr.Len() > 0 {
+			// Parse errors are reported as diagnostics rather than answer records.
+			w.Flush()
+			fmt.Fprintf(os.Stdout, "%s:\n%s", key, stderr.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWatchPatterns(t *testing.T) {
+	root, err := moduleRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataDir := filepath.Join(root, defaultDataDir)
+	patterns, err := watchPatterns(root, 5, dataDir, filepath.Join(dataDir, "answers.json"))
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+
+	pattern := func(pkg string) string { return filepath.Join(root, pkg, "*.go") }
+	for _, expected := range []string{
+		pattern("days/day5"), pattern("aoc"), pattern("parse"), pattern("cmd/aoc"),
+		// Packages of the runner, as well as the day's.
+		pattern("diag"), pattern("manifest"), pattern("cache"), pattern("vault"),
+		filepath.Join(dataDir, "day5", "*"),
+	} {
+		if !slices.Contains(patterns, expected) {
+			t.Errorf("got patterns %v, expected them to include %s", patterns, expected)
+		}
+	}
+	if slices.Contains(patterns, pattern("days/day4")) {
+		t.Errorf("got patterns %v, expected them to exclude the other days", patterns)
+	}
+}
@@ -0,0 +1,126 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// Separator is how the columns of a location list are separated.
+type Separator int
+
+const (
+	ThreeSpaces Separator = iota // exactly three spaces, as in the puzzle input
+	Whitespace                   // any run of spaces and tabs
+	CSV                          // a comma
+	TSV                          // a tab
+)
+
+// ListFormat is the layout of a location list. The zero value is the puzzle
+// input's own: two columns separated by exactly three spaces.
+type ListFormat struct {
+	Separator Separator
+	Columns   int // number of columns on each line (2 if 0)
+}
+
+func (f ListFormat) columns() int {
+	if f.Columns == 0 {
+		return 2
+	}
+	return f.Columns
+}
+
+// fields splits a line of the list into its columns.
+func (f ListFormat) fields(line parse.Line) ([]parse.Field, error) {
+	switch f.Separator {
+	case Whitespace:
+		return line.Fields(f.columns())
+	case CSV:
+		return line.Split(",", f.columns())
+	case TSV:
+		return line.Split("\t", f.columns())
+	default:
+		return line.Split("   ", f.columns())
+	}
+}
+
+// ParseLocationColumns parses a location list in the given format and returns
+// its columns, which all have the same number of elements. As with
+// ParseLocationList, every line must have every column; CSV fields can't be
+// quoted.
+func ParseLocationColumns(reader io.Reader, format ListFormat) ([][]uint64, error) {
+	if format.Columns < 0 || format.Separator < ThreeSpaces || format.Separator > TSV {
+		return nil, fmt.Errorf("invalid location list format %+v", format)
+	}
+
+	columns := make([][]uint64, format.columns())
+	lines := parse.NewLines(reader)
+	for lines.Next() {
+		line := lines.Line()
+
+		fields, err := format.fields(line)
+		if err != nil {
+			return nil, err
+		}
+		for i, field := range fields {
+			v, err := line.Uint(field)
+			if err != nil {
+				return nil, err
+			}
+			columns[i] = append(columns[i], v)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// ColumnPair picks two columns of a location list, numbered from 0, to be
+// compared as the left and right lists.
+type ColumnPair struct {
+	Left, Right int
+}
+
+// AllPairs returns every pair of n columns, each once, in order. (Both the
+// distance and the similarity score are the same either way round.)
+func AllPairs(n int) []ColumnPair {
+	var pairs []ColumnPair
+	for left := 0; left < n; left++ {
+		for right := left + 1; right < n; right++ {
+			pairs = append(pairs, ColumnPair{left, right})
+		}
+	}
+	return pairs
+}
+
+// PairResult is the total distance and similarity score of a pair of columns.
+type PairResult struct {
+	ColumnPair
+	Distance   uint64
+	Similarity uint64
+}
+
+// CalcPairs calculates the total distance (see CalcListDistance) and the
+// similarity score (see CalcSimilarityScore) of each pair of columns. Like
+// CalcListDistance, it sorts the columns.
+func CalcPairs(columns [][]uint64, pairs []ColumnPair) ([]PairResult, error) {
+	results := make([]PairResult, 0, len(pairs))
+	for _, pair := range pairs {
+		for _, column := range []int{pair.Left, pair.Right} {
+			if column < 0 || column >= len(columns) {
+				return nil, fmt.Errorf("no column %d (the list has %d)", column, len(columns))
+			}
+		}
+
+		left, right := columns[pair.Left], columns[pair.Right]
+		results = append(results, PairResult{
+			ColumnPair: pair,
+			Distance:   CalcListDistance(left, right),
+			Similarity: CalcSimilarityScore(left, right),
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,105 @@
+package day1
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLocationColumns(t *testing.T) {
+	var tests = []struct {
+		input         string
+		format        ListFormat
+		expected      [][]uint64
+		expectedError bool
+	}{
+		{"1   2\n3   4", ListFormat{}, [][]uint64{{1, 3}, {2, 4}}, false},
+		{"1\t2", ListFormat{}, nil, true},
+		{"1 2\n3\t4\n 5  \t 6 ", ListFormat{Separator: Whitespace}, [][]uint64{{1, 3, 5}, {2, 4, 6}}, false},
+		{"1 2 3", ListFormat{Separator: Whitespace}, nil, true},
+		{"1,2,3\n4,5,6", ListFormat{Separator: CSV, Columns: 3}, [][]uint64{{1, 4}, {2, 5}, {3, 6}}, false},
+		{"1,2\n3", ListFormat{Separator: CSV}, nil, true},
+		{"1, 2", ListFormat{Separator: CSV}, nil, true},
+		{"1\t2\r\n3\t4\r\n", ListFormat{Separator: TSV}, [][]uint64{{1, 3}, {2, 4}}, false},
+		{"1   2   3   4", ListFormat{Columns: 4}, [][]uint64{{1}, {2}, {3}, {4}}, false},
+		{"7", ListFormat{Columns: 1}, [][]uint64{{7}}, false},
+		{"", ListFormat{Columns: 3}, [][]uint64{nil, nil, nil}, false},
+		{"1   2", ListFormat{Columns: -1}, nil, true},
+		{"1   2", ListFormat{Separator: TSV + 1}, nil, true},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			columns, err := ParseLocationColumns(strings.NewReader(tt.input), tt.format)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("got %v, expected an error", columns)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if !reflect.DeepEqual(columns, tt.expected) {
+				t.Errorf("got %v, expected %v", columns, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalcPairs(t *testing.T) {
+	// The example's lists, and the left list again in reverse.
+	input := "3   4   3\n4   3   3\n2   5   3\n1   3   1\n3   9   2\n3   3   4\n"
+	columns, err := ParseLocationColumns(strings.NewReader(input), ListFormat{Columns: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pairs := AllPairs(3); !reflect.DeepEqual(pairs, []ColumnPair{{0, 1}, {0, 2}, {1, 2}}) {
+		t.Errorf("got pairs %v, expected each pair of 3 columns once", pairs)
+	}
+	results, err := CalcPairs(columns, AllPairs(3))
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	expected := []PairResult{
+		{ColumnPair{0, 1}, 11, 31},
+		{ColumnPair{0, 2}, 0, 1 + 2 + 3*3*3 + 4},
+		{ColumnPair{1, 2}, 11, 31},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("got %v, expected %v", results, expected)
+	}
+
+	if _, err := CalcPairs(columns, []ColumnPair{{0, 3}}); err == nil {
+		t.Errorf("got nil error for a missing column, expected !nil")
+	}
+}
+
+func FuzzParseLocationColumns(f *testing.F) {
+	f.Add([]byte("1   2\n3   4"), uint8(ThreeSpaces), uint8(2))
+	f.Add([]byte("1 2\t3\n4  5 6"), uint8(Whitespace), uint8(3))
+	f.Add([]byte("1,2\r\n3,4"), uint8(CSV), uint8(2))
+	f.Add([]byte("1\t2"), uint8(TSV), uint8(2))
+	f.Fuzz(func(t *testing.T, data []byte, separator uint8, n uint8) {
+		format := ListFormat{Separator: Separator(separator % 4), Columns: int(n%5) + 1}
+		columns, err := ParseLocationColumns(bytes.NewReader(data), format)
+		if err != nil {
+			return
+		}
+		if len(columns) != format.Columns {
+			t.Fatalf("got %d columns, expected %d", len(columns), format.Columns)
+		}
+		for _, column := range columns {
+			if len(column) != len(columns[0]) {
+				t.Fatalf("got columns of length %d and %d, expected equal lengths", len(column), len(columns[0]))
+			}
+		}
+		if _, err := CalcPairs(columns, AllPairs(len(columns))); err != nil {
+			t.Fatalf("got error %v, expected nil", err)
+		}
+	})
+}
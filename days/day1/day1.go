@@ -0,0 +1,139 @@
+// Package day1 solves day 1 of Advent of Code 2024: Historian Hysteria.
+package day1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// ParseLocationList parses a location list and returns two lists (one for each column).
+// The two returned lists are guaranteed to have the same number of elements.
+//
+// This function assumes the input conforms to the following grammar:
+//
+//	LocationList ::= (LocationListPair ('\n')? )*
+//	LocationListPair ::= (Digits) ('   ') (Digits)
+//	Digits ::= #'[0-9]+'
+//
+// (ParseLocationColumns parses lists in other formats.)
+func ParseLocationList(reader io.Reader) (locationList1 []uint64, locationList2 []uint64, err error) {
+	columns, err := ParseLocationColumns(reader, ListFormat{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return columns[0], columns[1], nil
+}
+
+// parseLocationPair parses a single LocationListPair.
+func parseLocationPair(line parse.Line) (uint64, uint64, error) {
+	fields, err := ListFormat{}.fields(line)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	first, err := line.Uint(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	second, err := line.Uint(fields[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return first, second, nil
+}
+
+func distance(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// CalcListDistance calculates the total distance of the two provided lists.
+// (This is for part 1 of the AOC challenge)
+func CalcListDistance(left []uint64, right []uint64) uint64 {
+	// Sort the lists in order to comply with the matching requirement:
+	//
+	// "... pair up the numbers and measure how far apart they are.
+	// Pair up the smallest number in the left list with the smallest number in the right list,
+	// then the second-smallest left number with the second-smallest right number, and so on."
+	sort.Slice(left, func(i, j int) bool { return left[i] < left[j] })
+	sort.Slice(right, func(i, j int) bool { return right[i] < right[j] })
+
+	// Calculate the total distance:
+	//
+	// "... Within each pair, figure out how far apart the two numbers are;
+	// you'll need to add up all of those distances."
+	var totalDistance uint64 = 0
+	for i := 0; i < len(left); i++ {
+		totalDistance += distance(left[i], right[i])
+	}
+
+	return totalDistance
+}
+
+// CalcSimilarityScore calculates the similarity score of the two provided lists.
+// (This is for part 2 of the AOC challenge)
+func CalcSimilarityScore(left []uint64, right []uint64) uint64 {
+	rightListOccurances := make(map[uint64]uint64)
+	for _, rv := range right {
+		rightListOccurances[rv] += 1
+	}
+
+	var similarityScore uint64
+	for _, lv := range left {
+		// If the left value (lv) has not occured in the right list,
+		// this map lookup will return 0, which makes the part 2 example(s).
+		similarityScore += lv * rightListOccurances[lv]
+	}
+	return similarityScore
+}
+
+// LocationLists is the parsed puzzle input, one list for each column.
+type LocationLists struct {
+	Left  []uint64
+	Right []uint64
+}
+
+// Solver solves day 1 and is registered with the aoc runner.
+type Solver struct{}
+
+func (Solver) Parse(r io.Reader) (LocationLists, error) {
+	left, right, err := ParseLocationList(r)
+	if err != nil {
+		return LocationLists{}, fmt.Errorf("error parsing location list: %w", err)
+	}
+	return LocationLists{left, right}, nil
+}
+
+func (Solver) Part1(ctx context.Context, input LocationLists) (aoc.Answer, error) {
+	return aoc.IntAnswer(CalcListDistance(input.Left, input.Right)), nil
+}
+
+func (Solver) Part2(ctx context.Context, input LocationLists) (aoc.Answer, error) {
+	return aoc.IntAnswer(CalcSimilarityScore(input.Left, input.Right)), nil
+}
+
+// SolveStream solves a part without holding the location list in memory (see
+// aoc.StreamSolver).
+func (Solver) SolveStream(ctx context.Context, part int, r io.Reader, memoryLimit int64) (aoc.Answer, error) {
+	solve := StreamListDistance
+	if part == 2 {
+		solve = StreamSimilarityScore
+	}
+	result, err := solve(ctx, r, memoryLimit)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.IntAnswer(result), nil
+}
+
+func init() {
+	aoc.Register[LocationLists](1, Solver{})
+}
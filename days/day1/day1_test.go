@@ -1,10 +1,14 @@
-package main
+package day1
 
 import (
+	"bytes"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
 )
 
 func TestParseLocationListEmpty(t *testing.T) {
@@ -75,7 +79,7 @@ func TestParseLocationListEmpty(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			reader := strings.NewReader(tt.input)
-			gotList1, gotList2, gotErr := parseLocationList(reader)
+			gotList1, gotList2, gotErr := ParseLocationList(reader)
 
 			if tt.expectedList1 != nil && !reflect.DeepEqual(gotList1, *tt.expectedList1) {
 				t.Errorf("got %v, expected %v", gotList1, *tt.expectedList1)
@@ -113,7 +117,7 @@ func TestCalcSimilarityScore(t *testing.T) {
 	for idx, tt := range tests {
 		testname := fmt.Sprintf("test_case_%v", idx)
 		t.Run(testname, func(t *testing.T) {
-			result := calcSimilarityScore(tt.list1, tt.list2)
+			result := CalcSimilarityScore(tt.list1, tt.list2)
 			if result != tt.expected {
 				t.Errorf("got %d, expected %d", result, tt.expected)
 			}
@@ -141,10 +145,25 @@ func TestCalcListDistance(t *testing.T) {
 	for idx, tt := range tests {
 		testname := fmt.Sprintf("test_case_%v", idx)
 		t.Run(testname, func(t *testing.T) {
-			result := calcListDistance(tt.list1, tt.list2)
+			result := CalcListDistance(tt.list1, tt.list2)
 			if result != tt.expected {
 				t.Errorf("got %d, expected %d", result, tt.expected)
 			}
 		})
 	}
 }
+
+func FuzzParseLocationList(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 1)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		left, right, err := ParseLocationList(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if len(left) != len(right) {
+			t.Fatalf("got lists of length %d and %d, expected equal lengths", len(left), len(right))
+		}
+		CalcListDistance(left, right)
+		CalcSimilarityScore(left, right)
+	})
+}
@@ -0,0 +1,572 @@
+package day1
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// The streaming mode solves location lists too large to hold in memory, such
+// as multi-gigabyte list dumps. Part 1 sorts each column with an external
+// merge sort, and part 2 counts the values in a map which spills to disk
+// when it's full. Both keep their temporary files in a directory which is
+// removed once they're done.
+
+const (
+	// MinMemoryLimit is the smallest memory limit of the streaming mode.
+	MinMemoryLimit = 1 << 20
+
+	// ioBufferSize is the buffer size of each temporary file being read or written.
+	ioBufferSize = 64 << 10
+
+	// mapEntryBytes is roughly the memory used by each value in a counting
+	// map, including the map's overhead (and its growth).
+	mapEntryBytes = 64
+
+	// checkInterval is how many values are processed between checks for cancellation.
+	checkInterval = 1 << 16
+)
+
+// streamLimits are the sizes which bound the streaming mode's memory use.
+type streamLimits struct {
+	chunkSize  int // values of each column sorted in memory at once
+	fanIn      int // sorted runs merged at once
+	maxEntries int // distinct values counted in memory at once
+	fanOut     int // partitions the counts are spilled to
+}
+
+// limitsFor returns the limits which keep the memory used within the given
+// number of bytes. The two columns' sorts share it equally; the counting
+// map uses half, and the buffers of its partitions the other half.
+func limitsFor(memoryLimit int64) (streamLimits, error) {
+	if memoryLimit < MinMemoryLimit {
+		return streamLimits{}, fmt.Errorf("memory limit of %d bytes is below the minimum of %d", memoryLimit, MinMemoryLimit)
+	}
+	half := memoryLimit / 2
+	buffers := int(half / ioBufferSize)
+	return streamLimits{
+		chunkSize:  int(half / 8),
+		fanIn:      max(buffers-1, 2), // leaving a buffer for writing the merged run
+		maxEntries: int(half / mapEntryBytes),
+		fanOut:     max(buffers, 2),
+	}, nil
+}
+
+// StreamListDistance calculates the total distance of the location list read
+// from r, like CalcListDistance, using about memoryLimit bytes of memory.
+func StreamListDistance(ctx context.Context, r io.Reader, memoryLimit int64) (uint64, error) {
+	limits, err := limitsFor(memoryLimit)
+	if err != nil {
+		return 0, err
+	}
+	return streamListDistance(ctx, r, limits)
+}
+
+func streamListDistance(ctx context.Context, r io.Reader, limits streamLimits) (uint64, error) {
+	dir, err := os.MkdirTemp("", "aoc-day1-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	left := &externalSorter{dir: dir, chunkSize: limits.chunkSize, fanIn: limits.fanIn}
+	right := &externalSorter{dir: dir, chunkSize: limits.chunkSize, fanIn: limits.fanIn}
+	err = streamLocationList(ctx, r, func(lv, rv uint64) error {
+		if err := left.Add(lv); err != nil {
+			return err
+		}
+		return right.Add(rv)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	leftSorted, err := left.Sorted(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer leftSorted.Close()
+	rightSorted, err := right.Sorted(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rightSorted.Close()
+
+	// Pair up the sorted columns, as CalcListDistance does.
+	var totalDistance uint64
+	for n := 1; ; n++ {
+		if n%checkInterval == 0 && ctx.Err() != nil {
+			return 0, aoc.Interrupted(ctx, "pairing up %d sorted locations", n)
+		}
+		lv, errLeft := leftSorted.Next()
+		rv, errRight := rightSorted.Next()
+		if errLeft == io.EOF && errRight == io.EOF {
+			break
+		}
+		if err := errors.Join(errLeft, errRight); err != nil {
+			return 0, err
+		}
+		totalDistance += distance(lv, rv)
+	}
+	return totalDistance, nil
+}
+
+// StreamSimilarityScore calculates the similarity score of the location list
+// read from r, like CalcSimilarityScore, using about memoryLimit bytes of memory.
+func StreamSimilarityScore(ctx context.Context, r io.Reader, memoryLimit int64) (uint64, error) {
+	limits, err := limitsFor(memoryLimit)
+	if err != nil {
+		return 0, err
+	}
+	return streamSimilarityScore(ctx, r, limits)
+}
+
+func streamSimilarityScore(ctx context.Context, r io.Reader, limits streamLimits) (uint64, error) {
+	dir, err := os.MkdirTemp("", "aoc-day1-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	counter := newSpillCounter(dir, limits, 0)
+	err = streamLocationList(ctx, r, func(lv, rv uint64) error {
+		if err := counter.Add(lv, valueCounts{left: 1}); err != nil {
+			return err
+		}
+		return counter.Add(rv, valueCounts{right: 1})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return counter.Score(ctx)
+}
+
+// streamLocationList calls fn with each pair of the location list, which
+// must have the grammar accepted by ParseLocationList.
+func streamLocationList(ctx context.Context, r io.Reader, fn func(left, right uint64) error) error {
+	lines := parse.NewLines(r)
+	for lines.Next() {
+		line := lines.Line()
+		if line.Number%checkInterval == 0 && ctx.Err() != nil {
+			return aoc.Interrupted(ctx, "reading %d lines", line.Number)
+		}
+
+		left, right, err := parseLocationPair(line)
+		if err != nil {
+			return fmt.Errorf("error parsing location list: %w", err)
+		}
+		if err := fn(left, right); err != nil {
+			return err
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return fmt.Errorf("error parsing location list: %w", err)
+	}
+	return nil
+}
+
+// values is a sequence of values, which returns io.EOF after the last one.
+type values interface {
+	Next() (uint64, error)
+	Close() error
+}
+
+// sliceValues is a sequence of values in memory.
+type sliceValues []uint64
+
+func (s *sliceValues) Next() (uint64, error) {
+	if len(*s) == 0 {
+		return 0, io.EOF
+	}
+	v := (*s)[0]
+	*s = (*s)[1:]
+	return v, nil
+}
+
+func (s *sliceValues) Close() error {
+	return nil
+}
+
+// externalSorter sorts more values than fit in memory. Values are sorted in
+// chunks of chunkSize, which are written to temporary files (runs), then
+// the runs are merged fanIn at a time until they can all be merged at once.
+type externalSorter struct {
+	dir       string
+	chunkSize int
+	fanIn     int
+
+	chunk []uint64
+	runs  []string // paths of the sorted runs
+}
+
+// Add adds a value to be sorted.
+func (s *externalSorter) Add(v uint64) error {
+	if len(s.chunk) == cap(s.chunk) {
+		// Grow the chunk gradually, so small inputs don't allocate a whole one.
+		s.chunk = slices.Grow(s.chunk, min(max(len(s.chunk), 1024), s.chunkSize-len(s.chunk)))
+	}
+	s.chunk = append(s.chunk, v)
+	if len(s.chunk) >= s.chunkSize {
+		return s.spill()
+	}
+	return nil
+}
+
+// spill sorts the chunk and writes it to a new run.
+func (s *externalSorter) spill() error {
+	slices.Sort(s.chunk)
+	chunk := sliceValues(s.chunk)
+	path, err := writeRun(s.dir, &chunk)
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, path)
+	s.chunk = s.chunk[:0]
+	return nil
+}
+
+// Sorted returns the values added so far in ascending order.
+func (s *externalSorter) Sorted(ctx context.Context) (values, error) {
+	if len(s.runs) == 0 {
+		slices.Sort(s.chunk)
+		sorted := sliceValues(s.chunk)
+		return &sorted, nil
+	}
+
+	if len(s.chunk) > 0 {
+		if err := s.spill(); err != nil {
+			return nil, err
+		}
+	}
+	s.chunk = nil
+
+	for len(s.runs) > s.fanIn {
+		if ctx.Err() != nil {
+			return nil, aoc.Interrupted(ctx, "merging %d sorted runs", len(s.runs))
+		}
+		merged, err := mergeRuns(s.runs[:s.fanIn])
+		if err != nil {
+			return nil, err
+		}
+		path, err := writeRun(s.dir, merged)
+		if closeErr := merged.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range s.runs[:s.fanIn] {
+			os.Remove(run)
+		}
+		s.runs = append(s.runs[s.fanIn:], path)
+	}
+	return mergeRuns(s.runs)
+}
+
+// writeRun writes the values to a new file in dir, and returns its path.
+func writeRun(dir string, vs values) (string, error) {
+	f, err := os.CreateTemp(dir, "run-*")
+	if err != nil {
+		return "", err
+	}
+	w := bufio.NewWriterSize(f, ioBufferSize)
+	var buf [8]byte
+	for {
+		v, err := vs.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			f.Close()
+			return "", err
+		}
+		binary.LittleEndian.PutUint64(buf[:], v)
+		if _, err := w.Write(buf[:]); err != nil {
+			f.Close()
+			return "", err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// runReader reads the values of a run.
+type runReader struct {
+	f   *os.File
+	r   *bufio.Reader
+	buf [8]byte
+}
+
+func openRun(path string) (*runReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &runReader{f: f, r: bufio.NewReaderSize(f, ioBufferSize)}, nil
+}
+
+func (r *runReader) Next() (uint64, error) {
+	if _, err := io.ReadFull(r.r, r.buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(r.buf[:]), nil
+}
+
+func (r *runReader) Close() error {
+	return r.f.Close()
+}
+
+// mergedRuns merges sorted runs into one sorted sequence, with a heap of
+// each run's next value.
+type mergedRuns struct {
+	runs []*runReader
+	next mergeHeap
+}
+
+type mergeItem struct {
+	value uint64
+	run   int
+}
+
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i].value < h[j].value }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)        { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+func mergeRuns(paths []string) (*mergedRuns, error) {
+	m := &mergedRuns{}
+	for i, path := range paths {
+		run, err := openRun(path)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.runs = append(m.runs, run)
+
+		v, err := run.Next()
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.next = append(m.next, mergeItem{v, i})
+	}
+	heap.Init(&m.next)
+	return m, nil
+}
+
+func (m *mergedRuns) Next() (uint64, error) {
+	if len(m.next) == 0 {
+		return 0, io.EOF
+	}
+	item := m.next[0]
+	v, err := m.runs[item.run].Next()
+	switch {
+	case err == io.EOF:
+		heap.Pop(&m.next)
+	case err != nil:
+		return 0, err
+	default:
+		m.next[0].value = v
+		heap.Fix(&m.next, 0)
+	}
+	return item.value, nil
+}
+
+func (m *mergedRuns) Close() error {
+	var errs []error
+	for _, run := range m.runs {
+		errs = append(errs, run.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// valueCounts is how many times a value occurs in each column.
+type valueCounts struct {
+	left, right uint64
+}
+
+// spillCounter counts how many times values occur in each column, in a map
+// of at most maxEntries values. When the map is full, its counts are added
+// to one of fanOut partition files (chosen by the value's hash), and it's
+// cleared. Each value's counts are then all in the same partition, so the
+// partitions can be totalled one at a time, each with a spillCounter of its
+// own (and a different hash, in case it's still too large).
+type spillCounter struct {
+	dir    string
+	limits streamLimits
+	depth  int // how many times the values have been partitioned
+
+	counts     map[uint64]valueCounts
+	partitions []*partitionWriter
+}
+
+func newSpillCounter(dir string, limits streamLimits, depth int) *spillCounter {
+	return &spillCounter{dir: dir, limits: limits, depth: depth, counts: map[uint64]valueCounts{}}
+}
+
+// Add adds to a value's counts.
+func (c *spillCounter) Add(v uint64, counts valueCounts) error {
+	total := c.counts[v]
+	total.left += counts.left
+	total.right += counts.right
+	c.counts[v] = total
+	if len(c.counts) >= c.limits.maxEntries {
+		return c.spill()
+	}
+	return nil
+}
+
+// spill adds the counts in the map to the partitions, and clears it.
+func (c *spillCounter) spill() error {
+	if c.partitions == nil {
+		for i := 0; i < c.limits.fanOut; i++ {
+			p, err := createPartition(c.dir)
+			if err != nil {
+				c.closePartitions()
+				return err
+			}
+			c.partitions = append(c.partitions, p)
+		}
+	}
+
+	for v, counts := range c.counts {
+		p := c.partitions[hash(v, c.depth)%uint64(len(c.partitions))]
+		if err := p.Write(v, counts); err != nil {
+			return err
+		}
+	}
+	clear(c.counts)
+	return nil
+}
+
+// Score returns the similarity score of the counted values: each left value
+// multiplied by the number of times it occurs in the right column.
+func (c *spillCounter) Score(ctx context.Context) (uint64, error) {
+	if c.partitions == nil {
+		var score uint64
+		for v, counts := range c.counts {
+			score += v * counts.left * counts.right
+		}
+		return score, nil
+	}
+
+	err := c.spill()
+	if closeErr := c.closePartitions(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	c.counts = nil
+
+	var score uint64
+	for i, p := range c.partitions {
+		if ctx.Err() != nil {
+			return 0, aoc.Interrupted(ctx, "totalling %d of %d partitions of counts (at depth %d)", i, len(c.partitions), c.depth)
+		}
+		partitionScore, err := c.scorePartition(ctx, p.path)
+		if err != nil {
+			return 0, err
+		}
+		score += partitionScore
+		os.Remove(p.path)
+	}
+	return score, nil
+}
+
+// scorePartition returns the similarity score of the values in a partition.
+func (c *spillCounter) scorePartition(ctx context.Context, path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	counter := newSpillCounter(c.dir, c.limits, c.depth+1)
+	r := bufio.NewReaderSize(f, ioBufferSize)
+	var buf [24]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		v := binary.LittleEndian.Uint64(buf[0:])
+		counts := valueCounts{binary.LittleEndian.Uint64(buf[8:]), binary.LittleEndian.Uint64(buf[16:])}
+		if err := counter.Add(v, counts); err != nil {
+			return 0, err
+		}
+	}
+	return counter.Score(ctx)
+}
+
+func (c *spillCounter) closePartitions() error {
+	var errs []error
+	for _, p := range c.partitions {
+		errs = append(errs, p.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// partitionWriter appends counts to a partition file.
+type partitionWriter struct {
+	path string
+	f    *os.File
+	w    *bufio.Writer
+	buf  [24]byte
+}
+
+func createPartition(dir string) (*partitionWriter, error) {
+	f, err := os.CreateTemp(dir, "partition-*")
+	if err != nil {
+		return nil, err
+	}
+	return &partitionWriter{path: f.Name(), f: f, w: bufio.NewWriterSize(f, ioBufferSize)}, nil
+}
+
+func (p *partitionWriter) Write(v uint64, counts valueCounts) error {
+	binary.LittleEndian.PutUint64(p.buf[0:], v)
+	binary.LittleEndian.PutUint64(p.buf[8:], counts.left)
+	binary.LittleEndian.PutUint64(p.buf[16:], counts.right)
+	_, err := p.w.Write(p.buf[:])
+	return err
+}
+
+func (p *partitionWriter) Close() error {
+	err := p.w.Flush()
+	if closeErr := p.f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// hash mixes the value with the partitioning depth (using the SplitMix64
+// finaliser), so values which shared a partition are spread out again when
+// it's partitioned further.
+func hash(v uint64, depth int) uint64 {
+	v += uint64(depth+1) * 0x9e3779b97f4a7c15
+	v = (v ^ (v >> 30)) * 0xbf58476d1ce4e5b9
+	v = (v ^ (v >> 27)) * 0x94d049bb133111eb
+	return v ^ (v >> 31)
+}
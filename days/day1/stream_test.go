@@ -0,0 +1,94 @@
+package day1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/difftest"
+)
+
+// tinyLimits spill after a few values, so small inputs exercise every path of
+// the streaming mode: many runs merged in several passes, and counts
+// partitioned again and again.
+var tinyLimits = streamLimits{chunkSize: 32, fanIn: 3, maxEntries: 32, fanOut: 3}
+
+func TestStreamDifferential(t *testing.T) {
+	reference := func(input []byte) (any, error) {
+		left, right, err := ParseLocationList(bytes.NewReader(input))
+		if err != nil {
+			return nil, err
+		}
+		return [2]uint64{CalcListDistance(left, right), CalcSimilarityScore(left, right)}, nil
+	}
+	streaming := func(limits streamLimits) difftest.Func {
+		return func(input []byte) (any, error) {
+			distance, err := streamListDistance(context.Background(), bytes.NewReader(input), limits)
+			if err != nil {
+				return nil, err
+			}
+			score, err := streamSimilarityScore(context.Background(), bytes.NewReader(input), limits)
+			if err != nil {
+				return nil, err
+			}
+			return [2]uint64{distance, score}, nil
+		}
+	}
+
+	t.Setenv("TMPDIR", t.TempDir())
+	limits, err := limitsFor(MinMemoryLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	difftest.Check(t, difftest.Options{Day: 1, Sizes: []int{1, 10, 500}, Seeds: 10}, reference, streaming(tinyLimits))
+	difftest.Check(t, difftest.Options{Day: 1, Sizes: []int{100000}, Seeds: 2}, reference, streaming(limits))
+
+	// Every temporary file is removed.
+	if entries, err := os.ReadDir(os.Getenv("TMPDIR")); err != nil || len(entries) != 0 {
+		t.Errorf("got %d temporary files left (err: %v), expected none", len(entries), err)
+	}
+}
+
+func TestStreamExample(t *testing.T) {
+	input := "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n"
+	var tests = []struct {
+		part     int
+		expected string
+	}{
+		{1, "11"},
+		{2, "31"},
+	}
+
+	for _, tt := range tests {
+		answer, err := Solver{}.SolveStream(context.Background(), tt.part, strings.NewReader(input), MinMemoryLimit)
+		if err != nil {
+			t.Fatalf("got error %v, expected nil", err)
+		}
+		if answer.String() != tt.expected {
+			t.Errorf("got %v, expected %v", answer, tt.expected)
+		}
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := StreamListDistance(ctx, strings.NewReader("1   2"), MinMemoryLimit-1); err == nil {
+		t.Errorf("got nil error for a memory limit below the minimum, expected !nil")
+	}
+	if _, err := streamListDistance(ctx, strings.NewReader("1   2\n1 6"), tinyLimits); err == nil {
+		t.Errorf("got nil error for an invalid line, expected !nil")
+	}
+	if _, err := streamSimilarityScore(ctx, strings.NewReader("1   2\n\n5   6"), tinyLimits); err == nil {
+		t.Errorf("got nil error for an empty line, expected !nil")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	input := strings.Repeat("1   2\n", checkInterval)
+	if _, err := StreamListDistance(cancelled, strings.NewReader(input), MinMemoryLimit); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, expected the part to be cancelled", err)
+	}
+}
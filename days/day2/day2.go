@@ -0,0 +1,204 @@
+// Package day2 solves day 2 of Advent of Code 2024: Red-Nosed Reports.
+package day2
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// Report is a single report of levels.
+type Report []uint64
+
+// ParseReportList parses a list of reports.
+// This function assumes the input conforms to the following grammar:
+//
+//	ReportList ::= (Report ('\n')?)+
+//	Report ::= (Level (' ')?)+
+//	Level ::= (Digits)
+//	Digits ::= #'[0-9]+'
+//
+// Each report is gauranteed to have at least two level values.
+func ParseReportList(reader io.Reader) (reports []Report, err error) {
+	lines := parse.NewLines(reader)
+	for lines.Next() {
+		line := lines.Line()
+
+		levels, err := line.Uints(" ")
+		if err != nil {
+			return nil, err
+		}
+		if len(levels) < 2 {
+			return nil, line.Errorf(0, "unexpected format of report, expected at least two level values")
+		}
+
+		reports = append(reports, Report(levels))
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("expected at least one report")
+	}
+
+	return
+}
+
+func absoluteDifference(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func isReportSafeRaw(report Report) bool {
+	// A single level can't change direction or differ too much from its
+	// neighbour. (The problem dampener can remove one of a report's two levels.)
+	if len(report) < 2 {
+		return true
+	}
+
+	increasing := report[0] < report[1]
+	checkLevelChangeSafe := func(x, y uint64) bool {
+		// "The levels are either all increasing or all decreasing."
+		directionChanged := (x < y) != increasing
+
+		// "Any two adjacent levels differ by at least one and at most three."
+		diff := absoluteDifference(x, y)
+		toleranceExceeded := diff < 1 || diff > 3
+
+		return !(directionChanged || toleranceExceeded)
+	}
+
+	for i := 0; i < len(report)-1; i++ {
+		safe := checkLevelChangeSafe(report[i], report[i+1])
+
+		if !safe {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsReportSafeReference reports whether the report is safe, optionally
+// allowing the "Problem Dampener" to remove a single bad level, by trying the
+// report without each of its levels in turn.
+//
+// It is the reference for IsReportSafe in the differential tests.
+func IsReportSafeReference(report Report, problemDampenerEnabled bool) bool {
+	if problemDampenerEnabled {
+		// NOTE(Andoryuuta): This permutation logic is gross, but I struggled to find a way
+		// to incorporate the "Problem Dampener" (skipping a single bad level) into the normal
+		// `isReportSafeRaw` function.
+		//
+		// Definitely worth looking at other people's solutions for this later to see how
+		// this can be improved / done correctly.
+		for i := 0; i < len(report); i++ {
+			permutation := make(Report, len(report)-1)
+			copy(permutation[:i], report[:i])
+			copy(permutation[i:], report[i+1:])
+			if isReportSafeRaw(permutation) {
+				return true
+			}
+		}
+	}
+
+	return isReportSafeRaw(report)
+}
+
+// firstUnsafeLevel returns the index of the first level which makes the report
+// unsafe, ignoring the level at index skip (if any), or -1 if it is safe.
+func firstUnsafeLevel(report Report, skip int) int {
+	prev, increasing := -1, false
+	for i := range report {
+		if i == skip {
+			continue
+		}
+		if prev < 0 {
+			prev = i
+			continue
+		}
+
+		x, y := report[prev], report[i]
+		if prev == 0 || (prev == 1 && skip == 0) {
+			increasing = x < y
+		}
+		diff := absoluteDifference(x, y)
+		if (x < y) != increasing || diff < 1 || diff > 3 {
+			return i
+		}
+		prev = i
+	}
+	return -1
+}
+
+// IsReportSafe reports whether the report is safe, optionally allowing the
+// "Problem Dampener" to remove a single bad level.
+//
+// Only a level next to the first unsafe one can be the bad level: removing a
+// level elsewhere leaves the unsafe change in place. (The direction is set by
+// the first two levels, which is why removing the level before the pair can
+// fix it.)
+func IsReportSafe(report Report, problemDampenerEnabled bool) bool {
+	unsafe := firstUnsafeLevel(report, -1)
+	if unsafe < 0 {
+		return true
+	}
+	if !problemDampenerEnabled {
+		return false
+	}
+
+	for skip := unsafe - 2; skip <= unsafe; skip++ {
+		if skip >= 0 && firstUnsafeLevel(report, skip) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CalcSafeReports counts the safe reports.
+// (This is for part 1, or part 2 with the problem dampener enabled)
+//
+// It stops early if ctx is done, reporting how many reports were checked.
+func CalcSafeReports(ctx context.Context, reports []Report, problemDampenerEnabled bool) (uint64, error) {
+	var safeCount uint64
+	for i, report := range reports {
+		if ctx.Err() != nil {
+			return 0, aoc.Interrupted(ctx, "checking %d of %d reports (%d safe so far)", i, len(reports), safeCount)
+		}
+		if IsReportSafe(report, problemDampenerEnabled) {
+			safeCount += 1
+		}
+	}
+	return uint64(safeCount), nil
+}
+
+// Solver solves day 2 and is registered with the aoc runner.
+type Solver struct{}
+
+func (Solver) Parse(r io.Reader) ([]Report, error) {
+	reports, err := ParseReportList(r)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing report list: %w", err)
+	}
+	return reports, nil
+}
+
+func (Solver) Part1(ctx context.Context, reports []Report) (aoc.Answer, error) {
+	safeReports, err := CalcSafeReports(ctx, reports, false)
+	return aoc.IntAnswer(safeReports), err
+}
+
+func (Solver) Part2(ctx context.Context, reports []Report) (aoc.Answer, error) {
+	safeReports, err := CalcSafeReports(ctx, reports, true)
+	return aoc.IntAnswer(safeReports), err
+}
+
+func init() {
+	aoc.Register[[]Report](2, Solver{})
+}
@@ -1,10 +1,18 @@
-package main
+package day2
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
+	"github.com/Andoryuuta/AdventOfCode2024/difftest"
 )
 
 func TestParseReportList(t *testing.T) {
@@ -79,7 +87,7 @@ func TestParseReportList(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			reader := strings.NewReader(tt.input)
-			gotReports, gotErr := parseReportList(reader)
+			gotReports, gotErr := ParseReportList(reader)
 
 			if tt.expectedError && gotErr == nil {
 				t.Errorf("got %v, expected nil", gotErr)
@@ -175,12 +183,24 @@ func TestIsReportSafe(t *testing.T) {
 			true,
 			true,
 		},
+
+		// Removing a level of a two level report leaves a safe report.
+		{
+			Report{0, 0},
+			false,
+			false,
+		},
+		{
+			Report{0, 0},
+			true,
+			true,
+		},
 	}
 
 	for idx, tt := range tests {
 		testname := fmt.Sprintf("test_case_%v", idx)
 		t.Run(testname, func(t *testing.T) {
-			result := isReportSafe(tt.report, tt.problemDampenerEnabled)
+			result := IsReportSafe(tt.report, tt.problemDampenerEnabled)
 			if result != tt.expected {
 				t.Errorf("got %v, expected %v", result, tt.expected)
 			}
@@ -223,10 +243,62 @@ func TestCalcSafeReports(t *testing.T) {
 	for idx, tt := range tests {
 		testname := fmt.Sprintf("test_case_%v", idx)
 		t.Run(testname, func(t *testing.T) {
-			result := calcSafeReports(tt.reports, tt.problemDampenerEnabled)
+			result, err := CalcSafeReports(context.Background(), tt.reports, tt.problemDampenerEnabled)
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
 			if result != tt.expected {
 				t.Errorf("got %v, expected %v", result, tt.expected)
 			}
 		})
 	}
 }
+
+func TestCalcSafeReportsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := CalcSafeReports(ctx, []Report{{1, 2}, {2, 3}}, true)
+	var interrupted *aoc.InterruptedError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &interrupted) {
+		t.Fatalf("got error %v, expected *aoc.InterruptedError wrapping %v", err, context.Canceled)
+	}
+	if expected := "checking 0 of 2 reports (0 safe so far)"; interrupted.Progress != expected {
+		t.Errorf("got progress %q, expected %q", interrupted.Progress, expected)
+	}
+}
+
+func FuzzParseReportList(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 2)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		reports, err := ParseReportList(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		for _, dampener := range []bool{false, true} {
+			if _, err := CalcSafeReports(context.Background(), reports, dampener); err != nil {
+				t.Errorf("got error %v, expected nil", err)
+			}
+		}
+	})
+}
+
+func TestIsReportSafeDifferential(t *testing.T) {
+	// safety returns whether each report is safe without and with the problem dampener.
+	safety := func(isReportSafe func(Report, bool) bool) difftest.Func {
+		return func(input []byte) (any, error) {
+			reports, err := ParseReportList(bytes.NewReader(input))
+			if err != nil {
+				return nil, err
+			}
+			var safe [][2]bool
+			for _, report := range reports {
+				safe = append(safe, [2]bool{isReportSafe(report, false), isReportSafe(report, true)})
+			}
+			return safe, nil
+		}
+	}
+
+	difftest.Check(t, difftest.Options{Day: 2, Sizes: []int{1, 10, 100}, Seeds: 50},
+		safety(IsReportSafeReference), safety(IsReportSafe))
+}
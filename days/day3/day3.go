@@ -0,0 +1,119 @@
+// Package day3 solves day 3 of Advent of Code 2024: Mull It Over.
+package day3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+)
+
+// Opcode identifies the operation of an Instruction.
+type Opcode uint
+
+const (
+	OP_DO Opcode = iota
+	OP_DONT
+	OP_MUL
+)
+
+// Instruction is a single instruction extracted from the corrupted memory.
+type Instruction struct {
+	Op   Opcode
+	Args []uint
+}
+
+// ExtractInstructions extracts the valid instructions from the corrupted memory.
+func ExtractInstructions(data []byte) ([]Instruction, error) {
+	re := regexp.MustCompile(`(do\(\)|don't\(\)|mul\((\d\d?\d?),(\d\d?\d?)\))`)
+	matches := re.FindAllSubmatch(data, -1)
+	if matches == nil {
+		return nil, fmt.Errorf("failed to match any expressions in input")
+	}
+
+	var instructions []Instruction
+	for _, matchGroups := range matches {
+		var instruction Instruction
+
+		fullMatchString := string(matchGroups[0])
+		if fullMatchString == "do()" {
+			instruction.Op = OP_DO
+		} else if fullMatchString == "don't()" {
+			instruction.Op = OP_DONT
+		} else if strings.HasPrefix(fullMatchString, "mul(") {
+			instruction.Op = OP_MUL
+
+			lefthand, err := strconv.Atoi(string(matchGroups[2]))
+			if err != nil {
+				return nil, err
+			}
+			instruction.Args = append(instruction.Args, uint(lefthand))
+
+			righthand, err := strconv.Atoi(string(matchGroups[3]))
+			if err != nil {
+				return nil, err
+			}
+			instruction.Args = append(instruction.Args, uint(righthand))
+		}
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions, nil
+}
+
+// EvaluateProgram sums the products of all enabled 'mul' instructions.
+// The do()/don't() toggle instructions are only evaluated for part 2.
+func EvaluateProgram(instructions []Instruction, evalToggleInstructions bool) uint {
+	mulEnabled := true
+	accumulator := uint(0)
+	for _, instruction := range instructions {
+		switch instruction.Op {
+		case OP_DO:
+			if evalToggleInstructions {
+				mulEnabled = true
+			}
+		case OP_DONT:
+			if evalToggleInstructions {
+				mulEnabled = false
+			}
+		case OP_MUL:
+			if mulEnabled {
+				product := instruction.Args[0] * instruction.Args[1]
+				accumulator += product
+			}
+		}
+	}
+
+	return accumulator
+}
+
+// Solver solves day 3 and is registered with the aoc runner.
+type Solver struct{}
+
+func (Solver) Parse(r io.Reader) ([]Instruction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	instructions, err := ExtractInstructions(data)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting instructions from input: %w", err)
+	}
+	return instructions, nil
+}
+
+func (Solver) Part1(ctx context.Context, instructions []Instruction) (aoc.Answer, error) {
+	return aoc.IntAnswer(EvaluateProgram(instructions, false)), nil
+}
+
+func (Solver) Part2(ctx context.Context, instructions []Instruction) (aoc.Answer, error) {
+	return aoc.IntAnswer(EvaluateProgram(instructions, true)), nil
+}
+
+func init() {
+	aoc.Register[[]Instruction](3, Solver{})
+}
@@ -1,8 +1,11 @@
-package main
+package day3
 
 import (
+	"path/filepath"
 	"slices"
 	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
 )
 
 func TestExtractInstructions(t *testing.T) {
@@ -16,10 +19,10 @@ func TestExtractInstructions(t *testing.T) {
 			"valid instructions extracted (part1 example)",
 			`xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))`,
 			&[]Instruction{
-				{op: OP_MUL, args: []uint{2, 4}},
-				{op: OP_MUL, args: []uint{5, 5}},
-				{op: OP_MUL, args: []uint{11, 8}},
-				{op: OP_MUL, args: []uint{8, 5}},
+				{Op: OP_MUL, Args: []uint{2, 4}},
+				{Op: OP_MUL, Args: []uint{5, 5}},
+				{Op: OP_MUL, Args: []uint{11, 8}},
+				{Op: OP_MUL, Args: []uint{8, 5}},
 			},
 			false,
 		},
@@ -27,12 +30,12 @@ func TestExtractInstructions(t *testing.T) {
 			"valid instructions extracted (part2 example)",
 			`xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))`,
 			&[]Instruction{
-				{op: OP_MUL, args: []uint{2, 4}},
-				{op: OP_DONT, args: []uint{}},
-				{op: OP_MUL, args: []uint{5, 5}},
-				{op: OP_MUL, args: []uint{11, 8}},
-				{op: OP_DO, args: []uint{}},
-				{op: OP_MUL, args: []uint{8, 5}},
+				{Op: OP_MUL, Args: []uint{2, 4}},
+				{Op: OP_DONT, Args: []uint{}},
+				{Op: OP_MUL, Args: []uint{5, 5}},
+				{Op: OP_MUL, Args: []uint{11, 8}},
+				{Op: OP_DO, Args: []uint{}},
+				{Op: OP_MUL, Args: []uint{8, 5}},
 			},
 			false,
 		},
@@ -40,7 +43,7 @@ func TestExtractInstructions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotInstructions, gotErr := extractInstructions([]byte(tt.input))
+			gotInstructions, gotErr := ExtractInstructions([]byte(tt.input))
 
 			if tt.expectedError && gotErr == nil {
 				t.Errorf("got %v, expected nil", gotErr)
@@ -57,12 +60,12 @@ func TestExtractInstructions(t *testing.T) {
 					got := gotInstructions[i]
 					expected := (*tt.expectedInstructions)[i]
 
-					if got.op != expected.op {
-						t.Errorf("got op %v, expected %v", got.op, expected.op)
+					if got.Op != expected.Op {
+						t.Errorf("got op %v, expected %v", got.Op, expected.Op)
 					}
 
-					if !slices.Equal(got.args, expected.args) {
-						t.Errorf("got args %+v, expected %+v", got.args, expected.args)
+					if !slices.Equal(got.Args, expected.Args) {
+						t.Errorf("got args %+v, expected %+v", got.Args, expected.Args)
 						return
 					}
 				}
@@ -81,12 +84,12 @@ func TestEvaluateProgram(t *testing.T) {
 		{
 			"valid example sum (without evaluating toggle instructions)",
 			[]Instruction{
-				{op: OP_MUL, args: []uint{2, 4}},
-				{op: OP_DONT, args: []uint{}},
-				{op: OP_MUL, args: []uint{5, 5}},
-				{op: OP_MUL, args: []uint{11, 8}},
-				{op: OP_DO, args: []uint{}},
-				{op: OP_MUL, args: []uint{8, 5}},
+				{Op: OP_MUL, Args: []uint{2, 4}},
+				{Op: OP_DONT, Args: []uint{}},
+				{Op: OP_MUL, Args: []uint{5, 5}},
+				{Op: OP_MUL, Args: []uint{11, 8}},
+				{Op: OP_DO, Args: []uint{}},
+				{Op: OP_MUL, Args: []uint{8, 5}},
 			},
 			false,
 			161,
@@ -94,12 +97,12 @@ func TestEvaluateProgram(t *testing.T) {
 		{
 			"valid example sum (with evaluating toggle instructions)",
 			[]Instruction{
-				{op: OP_MUL, args: []uint{2, 4}},
-				{op: OP_DONT, args: []uint{}},
-				{op: OP_MUL, args: []uint{5, 5}},
-				{op: OP_MUL, args: []uint{11, 8}},
-				{op: OP_DO, args: []uint{}},
-				{op: OP_MUL, args: []uint{8, 5}},
+				{Op: OP_MUL, Args: []uint{2, 4}},
+				{Op: OP_DONT, Args: []uint{}},
+				{Op: OP_MUL, Args: []uint{5, 5}},
+				{Op: OP_MUL, Args: []uint{11, 8}},
+				{Op: OP_DO, Args: []uint{}},
+				{Op: OP_MUL, Args: []uint{8, 5}},
 			},
 			true,
 			48,
@@ -108,7 +111,7 @@ func TestEvaluateProgram(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotSum := evaluateProgram(tt.program, tt.evaluateToggleInstructions)
+			gotSum := EvaluateProgram(tt.program, tt.evaluateToggleInstructions)
 
 			if gotSum != tt.expectedProductSum {
 				t.Errorf("got product sum %v, expected %v", gotSum, tt.expectedProductSum)
@@ -116,3 +119,15 @@ func TestEvaluateProgram(t *testing.T) {
 		})
 	}
 }
+
+func FuzzExtractInstructions(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 3)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		instructions, err := ExtractInstructions(data)
+		if err != nil {
+			return
+		}
+		EvaluateProgram(instructions, false)
+		EvaluateProgram(instructions, true)
+	})
+}
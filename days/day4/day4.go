@@ -1,62 +1,33 @@
-package main
+// Package day4 solves day 4 of Advent of Code 2024: Ceres Search.
+package day4
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/grid"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
 )
 
-// "parses" the word-search input into a 2D array of runes.
+// ParseWordSearch "parses" the word-search input into a 2D grid of runes.
 // This function requires that all input rows are the same length.
-func parseWordSearch(reader io.Reader) ([][]rune, error) {
-	// Convert data into 2D rune arrays
-	var data2d [][]rune
-
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() {
-		row := []rune(scanner.Text())
-
-		// Verify all rows are the same length
-		if len(data2d) > 0 && len(data2d[0]) != len(row) {
-			return nil, fmt.Errorf("expected all rows in the input to be the same length")
-		}
-
-		data2d = append(data2d, row)
-	}
-	err := scanner.Err()
-	if err != nil {
-		return nil, err
-	}
-	return data2d, nil
+func ParseWordSearch(reader io.Reader) (*grid.Grid[rune], error) {
+	return parse.Grid(reader)
 }
 
-// Point represents a single point in a 2D array
-// This struct uses row/col (rather than x/y) for clarity.
-type Point struct {
-	row uint
-	col uint
-}
-
-func shapeMatch(data2d [][]rune, shape [][]rune, mask [][]bool, matchRow uint, matchCol uint) bool {
-	runeAt := func(row uint, col uint) *rune {
-		if row < uint(len(data2d)) && col < uint(len(data2d[row])) {
-			return &data2d[row][col]
-		}
-		return nil
-	}
-
-	for srow := uint(0); srow < uint(len(shape)); srow++ {
-		for scol := uint(0); scol < uint(len(shape[srow])); scol++ {
+func shapeMatch(data2d *grid.Grid[rune], shape [][]rune, mask [][]bool, match grid.Point) bool {
+	for srow := 0; srow < len(shape); srow++ {
+		for scol := 0; scol < len(shape[srow]); scol++ {
 			// This part of the shape is masked off, don't check it.
 			if !mask[srow][scol] {
 				continue
 			}
 
-			dRune := runeAt(matchRow+srow, matchCol+uint(scol))
+			dRune, ok := data2d.At(match.Add(grid.Point{Row: srow, Col: scol}))
 			sRune := shape[srow][scol]
-			if dRune == nil || *dRune != sRune {
+			if !ok || dRune != sRune {
 				return false
 			}
 		}
@@ -65,23 +36,21 @@ func shapeMatch(data2d [][]rune, shape [][]rune, mask [][]bool, matchRow uint, m
 	return true
 }
 
-func searchShape(data2d [][]rune, shape [][]rune, mask [][]bool) []Point {
-	var matches []Point
-	for row := uint(0); row < uint(len(data2d)); row++ {
-		for col := uint(0); col < uint(len(data2d[row])); col++ {
-			matchResult := shapeMatch(data2d, shape, mask, row, col)
-			if matchResult {
-				matches = append(matches, Point{row, col})
-			}
+// SearchShape returns the top-left point of every match of the (masked) shape.
+func SearchShape(data2d *grid.Grid[rune], shape [][]rune, mask [][]bool) []grid.Point {
+	var matches []grid.Point
+	data2d.Each(func(p grid.Point, _ rune) {
+		if shapeMatch(data2d, shape, mask, p) {
+			matches = append(matches, p)
 		}
-	}
+	})
 
 	return matches
 }
 
-// countXmasShapePart1 counts instances of "XMAS" in the input
+// CountXmasShapePart1 counts instances of "XMAS" in the input
 // (horizontal/vertical/diagonal, allowing reverse spelling)
-func countXmasShapePart1(data2d [][]rune) uint {
+func CountXmasShapePart1(data2d *grid.Grid[rune]) uint {
 	shapes := []struct {
 		shape [][]rune
 		mask  [][]bool
@@ -197,7 +166,7 @@ func countXmasShapePart1(data2d [][]rune) uint {
 
 	count := 0
 	for _, shapeGroup := range shapes {
-		points := searchShape(
+		points := SearchShape(
 			data2d,
 			shapeGroup.shape,
 			shapeGroup.mask,
@@ -209,8 +178,8 @@ func countXmasShapePart1(data2d [][]rune) uint {
 	return uint(count)
 }
 
-// countXmasShapePart1 counts instance of cross "MAS" shapes in the input
-func countXmasShapePart2(data2d [][]rune) uint {
+// CountXmasShapePart2 counts instance of cross "MAS" shapes in the input
+func CountXmasShapePart2(data2d *grid.Grid[rune]) uint {
 	crossShapeMask := [][]bool{
 		{true, false, true},
 		{false, true, false},
@@ -257,7 +226,7 @@ func countXmasShapePart2(data2d [][]rune) uint {
 
 	count := 0
 	for _, shapeGroup := range shapes {
-		points := searchShape(
+		points := SearchShape(
 			data2d,
 			shapeGroup.shape,
 			shapeGroup.mask,
@@ -268,26 +237,25 @@ func countXmasShapePart2(data2d [][]rune) uint {
 	return uint(count)
 }
 
-func main() {
-	if len(os.Args) != 2 {
-		log.Fatalf("must provide input filename as an argument")
-		return
-	}
-	filename := os.Args[1]
+// Solver solves day 4 and is registered with the aoc runner.
+type Solver struct{}
 
-	file, err := os.Open(filename)
+func (Solver) Parse(r io.Reader) (*grid.Grid[rune], error) {
+	wordSearch, err := ParseWordSearch(r)
 	if err != nil {
-		log.Fatalf("cannot open input file: %v\n", err)
+		return nil, fmt.Errorf("error parsing word-search input: %w", err)
 	}
+	return wordSearch, nil
+}
 
-	wordSearch, err := parseWordSearch(file)
-	if err != nil {
-		log.Fatalf("error parsing word-search input: %v\n", err)
-	}
+func (Solver) Part1(ctx context.Context, wordSearch *grid.Grid[rune]) (aoc.Answer, error) {
+	return aoc.IntAnswer(CountXmasShapePart1(wordSearch)), nil
+}
 
-	countPart1 := countXmasShapePart1(wordSearch)
-	fmt.Printf("Horizontal/Vertical/Diagonal count (part 1): %d\n", countPart1)
+func (Solver) Part2(ctx context.Context, wordSearch *grid.Grid[rune]) (aoc.Answer, error) {
+	return aoc.IntAnswer(CountXmasShapePart2(wordSearch)), nil
+}
 
-	countPart2 := countXmasShapePart2(wordSearch)
-	fmt.Printf("Cross-'MAS' shape count (part 2): %d\n", countPart2)
+func init() {
+	aoc.Register[*grid.Grid[rune]](4, Solver{})
 }
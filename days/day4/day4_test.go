@@ -1,9 +1,14 @@
-package main
+package day4
 
 import (
+	"bytes"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
+	"github.com/Andoryuuta/AdventOfCode2024/grid"
 )
 
 func TestParseWordsearch(t *testing.T) {
@@ -33,20 +38,22 @@ func TestParseWordsearch(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			reader := strings.NewReader(tt.input)
-			gotOutput, gotErr := parseWordSearch(reader)
+			gotOutput, gotErr := ParseWordSearch(reader)
 
-			if tt.expectedError && gotErr == nil {
-				t.Errorf("got error %v, expected nil", gotErr)
+			if tt.expectedError {
+				if gotErr == nil {
+					t.Errorf("got error %v, expected !nil", gotErr)
+				}
 				return
 			}
 
-			if len(gotOutput) != len(tt.expectedOutput) {
-				t.Errorf("got output length %v, expected %v", len(gotOutput), len(tt.expectedOutput))
+			if gotOutput.Rows() != len(tt.expectedOutput) {
+				t.Errorf("got output length %v, expected %v", gotOutput.Rows(), len(tt.expectedOutput))
 				return
 			}
 
-			for i := 0; i < len(gotOutput); i++ {
-				got := gotOutput[i]
+			for i := 0; i < gotOutput.Rows(); i++ {
+				got := gotOutput.Row(i)
 				expected := tt.expectedOutput[i]
 				if !slices.Equal(got, expected) {
 					t.Errorf("got args %+v, expected %+v", got, expected)
@@ -63,7 +70,7 @@ func TestSearchShape(t *testing.T) {
 		inputWordSearch [][]rune
 		inputShape      [][]rune
 		inputShapeMask  [][]bool
-		expectedOutput  []Point
+		expectedOutput  []grid.Point
 	}{
 		{
 			"Valid row matching",
@@ -79,9 +86,9 @@ func TestSearchShape(t *testing.T) {
 			[][]bool{
 				{true, true, true, true},
 			},
-			[]Point{
-				{0, 0},
-				{2, 0},
+			[]grid.Point{
+				{Row: 0, Col: 0},
+				{Row: 2, Col: 0},
 			},
 		},
 		{
@@ -100,8 +107,8 @@ func TestSearchShape(t *testing.T) {
 				{true, true},
 				{true, true},
 			},
-			[]Point{
-				{1, 1},
+			[]grid.Point{
+				{Row: 1, Col: 1},
 			},
 		},
 		{
@@ -130,9 +137,9 @@ func TestSearchShape(t *testing.T) {
 				{true, false, false},
 				{true, true, true},
 			},
-			[]Point{
-				{2, 1},
-				{9, 2},
+			[]grid.Point{
+				{Row: 2, Col: 1},
+				{Row: 9, Col: 2},
 			},
 		},
 		{
@@ -158,16 +165,20 @@ func TestSearchShape(t *testing.T) {
 				{true, false, false},
 				{true, true, true},
 			},
-			[]Point{
-				{2, 1},
-				{4, 2},
+			[]grid.Point{
+				{Row: 2, Col: 1},
+				{Row: 4, Col: 2},
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotOutput := searchShape(tt.inputWordSearch, tt.inputShape, tt.inputShapeMask)
+			wordSearch, err := grid.FromRows(tt.inputWordSearch)
+			if err != nil {
+				t.Fatalf("invalid test word search: %v", err)
+			}
+			gotOutput := SearchShape(wordSearch, tt.inputShape, tt.inputShapeMask)
 
 			if len(gotOutput) != len(tt.expectedOutput) {
 				t.Errorf("got output length %v, expected %v", len(gotOutput), len(tt.expectedOutput))
@@ -183,3 +194,15 @@ func TestSearchShape(t *testing.T) {
 		})
 	}
 }
+
+func FuzzParseWordSearch(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 4)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		wordSearch, err := ParseWordSearch(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		CountXmasShapePart1(wordSearch)
+		CountXmasShapePart2(wordSearch)
+	})
+}
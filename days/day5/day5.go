@@ -1,85 +1,88 @@
-package main
+// Package day5 solves day 5 of Advent of Code 2024: Print Queue.
+package day5
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
 )
 
 type PageID int
 type PageList []PageID
 
+// UpdateSummary is the parsed puzzle input.
 type UpdateSummary struct {
 	// mapping of page number -> any dependency page numbers.
-	orderingRules map[PageID][]PageID
+	OrderingRules map[PageID][]PageID
 
-	updates []PageList
+	Updates []PageList
 }
 
-func parseUpdateSummary(reader io.Reader) (*UpdateSummary, error) {
+// ParseUpdateSummary parses the page ordering rules and the update page lists,
+// which are separated by an empty line.
+func ParseUpdateSummary(reader io.Reader) (*UpdateSummary, error) {
 	summary := &UpdateSummary{
-		orderingRules: map[PageID][]PageID{},
-		updates:       []PageList{},
+		OrderingRules: map[PageID][]PageID{},
+		Updates:       []PageList{},
 	}
 
-	parsingOrderingRules := true
-	scanner := bufio.NewScanner(reader)
-	lineNumber := 1
-	for scanner.Scan() {
-		lineNumber += 1
-		line := scanner.Text()
-
-		// First empty line is the separator between the ordering rules
-		// and the update page list.
-		if parsingOrderingRules && line == "" {
-			parsingOrderingRules = false
-			continue
-		}
+	// First empty line is the separator between the ordering rules
+	// and the update page list.
+	sections, err := parse.Sections(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(sections) > 2 {
+		// Trailing blank lines are ignored, so the last section always has a line to point at.
+		extraLine := sections[len(sections)-1][0]
+		return nil, extraLine.Errorf(0, "expected a single empty line separating the ordering rules and the updates")
+	}
 
-		if parsingOrderingRules {
-			split := strings.Split(line, "|")
-			if len(split) != 2 {
-				return nil, fmt.Errorf("expected page ordering rule on line %d, got:%v", lineNumber, line)
+	if len(sections) > 0 {
+		for _, line := range sections[0] {
+			fields, err := line.Split("|", 2)
+			if err != nil {
+				return nil, err
 			}
 
-			depPageID, err := strconv.Atoi(split[0])
+			depPageID, err := line.Int(fields[0])
 			if err != nil {
-				return nil, fmt.Errorf("error on line %d: %v", err)
+				return nil, err
 			}
 
-			pageID, err := strconv.Atoi(split[1])
+			pageID, err := line.Int(fields[1])
 			if err != nil {
-				return nil, fmt.Errorf("error on line %d: %v", err)
+				return nil, err
 			}
 
 			typedPageID := PageID(pageID)
-			summary.orderingRules[typedPageID] = append(summary.orderingRules[typedPageID], PageID(depPageID))
-		} else {
+			summary.OrderingRules[typedPageID] = append(summary.OrderingRules[typedPageID], PageID(depPageID))
+		}
+	}
+
+	if len(sections) > 1 {
+		for _, line := range sections[1] {
+			pageIDs, err := line.Ints(",")
+			if err != nil {
+				return nil, err
+			}
+
 			var update PageList
-			split := strings.Split(line, ",")
-			for _, pageID := range split {
-				parsedPageID, err := strconv.Atoi(pageID)
-				if err != nil {
-					return nil, fmt.Errorf("error on line %d: %v", err)
-				}
-				update = append(update, PageID(parsedPageID))
+			for _, pageID := range pageIDs {
+				update = append(update, PageID(pageID))
 			}
-			summary.updates = append(summary.updates, update)
+			summary.Updates = append(summary.Updates, update)
 		}
 	}
-	err := scanner.Err()
-	if err != nil {
-		return nil, err
-	}
 
 	return summary, nil
 }
 
-func isPageListCompliant(orderingRules map[PageID][]PageID, pages PageList) bool {
+// IsPageListCompliant reports whether the page list satisfies every applicable ordering rule.
+func IsPageListCompliant(orderingRules map[PageID][]PageID, pages PageList) bool {
 	// Build a map lookup rather than array scanning
 	contains := map[PageID]bool{}
 	for _, pageID := range pages {
@@ -129,40 +132,10 @@ func getMinimalOrderingRules(orderingRules map[PageID][]PageID, pages PageList)
 	return minimalOrderingRules
 }
 
-func generateMermaidGraph(orderingRules map[PageID][]PageID, pages PageList) string {
-	minimalOrderingRules := getMinimalOrderingRules(orderingRules, pages)
-	output := "graph LR\n"
-	joined := ""
-	for idx, pageID := range pages {
-		if idx == len(pages)-1 {
-			joined += fmt.Sprintf("%d", pageID)
-		} else {
-			joined += fmt.Sprintf("%d, ", pageID)
-		}
-	}
-	output += fmt.Sprintf("subgraph \"Pages: %v\"\n", joined)
-
-	// Emit all page IDs (required to have a node for any non-connected node)
-	for _, pageID := range pages {
-		output += fmt.Sprintf("%d\n", pageID)
-	}
-
-	// Emit all page dependencies
-	for pageID, dependencies := range minimalOrderingRules {
-		for _, depPageID := range dependencies {
-			output += fmt.Sprintf("%d --> %d\n", pageID, depPageID)
-		}
-	}
-
-	output += "end\n"
-
-	return output
-}
-
-// pseudoTopSortPageList attempts a pseudo-topological sort to find a solution.
+// PseudoTopSortPageList attempts a pseudo-topological sort to find a solution.
 // This is "pseudo" because I don't know graph theory.
 // TODO(Andoryuuta): learn graph theory?
-func pseudoTopSortPageList(orderingRules map[PageID][]PageID, invalidPageList PageList) (PageList, error) {
+func PseudoTopSortPageList(orderingRules map[PageID][]PageID, invalidPageList PageList) (PageList, error) {
 	// 0. Create list of each node and it's dependencies
 	// 1. Emit whichever node doesn't have any dependencies
 	// 2. Remove the node emitted in step 1 with from the dependency list of any other node
@@ -224,12 +197,16 @@ func pseudoTopSortPageList(orderingRules map[PageID][]PageID, invalidPageList Pa
 	return solution, nil
 }
 
-// calculatePartOneSolution calculates the part 1 solution by filtering the
+// CalculatePartOneSolution calculates the part 1 solution by filtering the
 // input by valid "update" page lists, then summing the middle page number of each.
-func calculatePartOneSolution(orderingRules map[PageID][]PageID, updates []PageList) int {
+func CalculatePartOneSolution(orderingRules map[PageID][]PageID, updates []PageList) (int, error) {
+	if err := checkUpdates(updates); err != nil {
+		return 0, err
+	}
+
 	var validPageLists []PageList
 	for _, pageList := range updates {
-		if isPageListCompliant(orderingRules, pageList) {
+		if IsPageListCompliant(orderingRules, pageList) {
 			validPageLists = append(validPageLists, pageList)
 		}
 	}
@@ -238,22 +215,27 @@ func calculatePartOneSolution(orderingRules map[PageID][]PageID, updates []PageL
 	for _, pageList := range validPageLists {
 		sum += int(pageList[len(pageList)/2])
 	}
-	return sum
+	return sum, nil
 }
 
-// calculatePartOneSolution calculates the part 1 solution by filtering the
-// input by valid "update" page lists, then summing the middle page number of each.
-func calculatePartTwoSolution(orderingRules map[PageID][]PageID, updates []PageList) (int, error) {
+// CalculatePartTwoSolution calculates the part 2 solution by filtering the
+// input by invalid "update" page lists, correcting their order, then summing
+// the middle page number of each.
+func CalculatePartTwoSolution(orderingRules map[PageID][]PageID, updates []PageList) (int, error) {
+	if err := checkUpdates(updates); err != nil {
+		return 0, err
+	}
+
 	var invalidPageLists []PageList
 	for _, pageList := range updates {
-		if !isPageListCompliant(orderingRules, pageList) {
+		if !IsPageListCompliant(orderingRules, pageList) {
 			invalidPageLists = append(invalidPageLists, pageList)
 		}
 	}
 
 	sum := 0
 	for _, pageList := range invalidPageLists {
-		correctedSolution, err := pseudoTopSortPageList(orderingRules, pageList)
+		correctedSolution, err := PseudoTopSortPageList(orderingRules, pageList)
 		if err != nil {
 			return 0, err
 		}
@@ -262,38 +244,43 @@ func calculatePartTwoSolution(orderingRules map[PageID][]PageID, updates []PageL
 	return sum, nil
 }
 
-func main() {
-	if len(os.Args) != 2 {
-		log.Fatalf("must provide input filename as an argument")
-		return
+// checkUpdates checks every update has a middle page.
+func checkUpdates(updates []PageList) error {
+	for i, pageList := range updates {
+		if len(pageList) == 0 {
+			return fmt.Errorf("update %d has no pages", i+1)
+		}
 	}
-	filename := os.Args[1]
+	return nil
+}
+
+// Solver solves day 5 and is registered with the aoc runner.
+type Solver struct{}
 
-	file, err := os.Open(filename)
+func (Solver) Parse(r io.Reader) (*UpdateSummary, error) {
+	updateSummary, err := ParseUpdateSummary(r)
 	if err != nil {
-		log.Fatalf("cannot open input file: %v\n", err)
+		return nil, fmt.Errorf("error parsing update summary input: %w", err)
 	}
+	return updateSummary, nil
+}
 
-	updateSummary, err := parseUpdateSummary(file)
+func (Solver) Part1(ctx context.Context, updateSummary *UpdateSummary) (aoc.Answer, error) {
+	partOneSolution, err := CalculatePartOneSolution(updateSummary.OrderingRules, updateSummary.Updates)
 	if err != nil {
-		log.Fatalf("error parsing update summary input: %v\n", err)
+		return aoc.Answer{}, fmt.Errorf("error calculating part 1 solution: %w", err)
 	}
-	// fmt.Printf("Summary: %+v\n", updateSummary)
-
-	partOneSolution := calculatePartOneSolution(updateSummary.orderingRules, updateSummary.updates)
-	fmt.Printf("Part 1 solution: %v\n", partOneSolution)
+	return aoc.IntAnswer(partOneSolution), nil
+}
 
-	partTwoSolution, err := calculatePartTwoSolution(updateSummary.orderingRules, updateSummary.updates)
+func (Solver) Part2(ctx context.Context, updateSummary *UpdateSummary) (aoc.Answer, error) {
+	partTwoSolution, err := CalculatePartTwoSolution(updateSummary.OrderingRules, updateSummary.Updates)
 	if err != nil {
-		log.Fatalf("error calculating part 2 solution: %v\n", err)
+		return aoc.Answer{}, fmt.Errorf("error calculating part 2 solution: %w", err)
 	}
-	fmt.Printf("Part 2 solution: %v\n", partTwoSolution)
-
-	// var mermaidGraph string
-	// mermaidGraph = generateMermaidGraph(updateSummary.orderingRules, []PageID{75, 97, 47, 61, 53})
-	// fmt.Printf("Mermaid Graph:\n%v\n", mermaidGraph)
-	// mermaidGraph = generateMermaidGraph(updateSummary.orderingRules, []PageID{61, 13, 29})
-	// fmt.Printf("Mermaid Graph:\n%v\n", mermaidGraph)
-	// mermaidGraph = generateMermaidGraph(updateSummary.orderingRules, []PageID{97, 13, 75, 29, 47})
-	// fmt.Printf("Mermaid Graph:\n%v\n", mermaidGraph)
+	return aoc.IntAnswer(partTwoSolution), nil
+}
+
+func init() {
+	aoc.Register[*UpdateSummary](5, Solver{})
 }
@@ -0,0 +1,75 @@
+package day5
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+func TestCalculateSolutionsEmptyUpdate(t *testing.T) {
+	orderingRules := map[PageID][]PageID{47: {97}}
+	updates := []PageList{{97, 47, 61}, {}}
+
+	var tests = []struct {
+		calculate func(map[PageID][]PageID, []PageList) (int, error)
+	}{
+		{CalculatePartOneSolution},
+		{CalculatePartTwoSolution},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			if _, err := tt.calculate(orderingRules, updates); err == nil {
+				t.Errorf("got nil error, expected an error for the empty update")
+			}
+		})
+	}
+}
+
+func FuzzParseUpdateSummary(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 5)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		summary, err := ParseUpdateSummary(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if _, err := CalculatePartOneSolution(summary.OrderingRules, summary.Updates); err != nil {
+			t.Errorf("got error %v, expected nil", err)
+		}
+		// Part 2 fails on cyclic ordering rules, which the parser doesn't reject.
+		CalculatePartTwoSolution(summary.OrderingRules, summary.Updates)
+	})
+}
+
+func TestParseUpdateSummaryRuleErrors(t *testing.T) {
+	var tests = []struct {
+		input          string
+		expectedLine   int
+		expectedColumn int
+	}{
+		{"47|53\n97-13\n\n75,47\n", 2, 0},
+		{"47|53|61\n\n75,47\n", 1, 6},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			_, err := ParseUpdateSummary(strings.NewReader(tt.input))
+			var parseErr *parse.Error
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("got error %v, expected a *parse.Error", err)
+			}
+			if parseErr.Line != tt.expectedLine || parseErr.Column != tt.expectedColumn || parseErr.Separator != "|" {
+				t.Errorf("got line %d, column %d, separator %q, expected line %d, column %d, separator \"|\"",
+					parseErr.Line, parseErr.Column, parseErr.Separator, tt.expectedLine, tt.expectedColumn)
+			}
+		})
+	}
+}
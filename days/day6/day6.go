@@ -0,0 +1,263 @@
+// Package day6 solves day 6 of Advent of Code 2024: Guard Gallivant.
+package day6
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/grid"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+type PuzzleMap struct {
+	MapData             *grid.Grid[rune]
+	GuardStartPosition  grid.Point
+	GuardStartDirection grid.Direction
+}
+
+// ParseMap parses the puzzle map input into a 2D grid of runes.
+func ParseMap(reader io.Reader) (*PuzzleMap, error) {
+	mapData, err := parse.Grid(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the guard's position and direction, then remove it from the map.
+	guardPosition, guardRune, ok := grid.Find(mapData, '^', '<', 'v', '>')
+	if !ok {
+		return nil, fmt.Errorf("expected a guard ('^', '<', 'v' or '>') in the map")
+	}
+	guardDirection, _ := grid.ParseArrow(guardRune)
+	mapData.Set(guardPosition, '.')
+
+	return &PuzzleMap{
+		MapData:             mapData,
+		GuardStartPosition:  guardPosition,
+		GuardStartDirection: guardDirection,
+	}, nil
+}
+
+// cancelCheckInterval is how many steps are simulated between checks for
+// cancellation, as checking on every step would noticeably slow the patrol.
+const cancelCheckInterval = 1 << 12
+
+// SimulateGuardPatrol simluates the guard patrol of the provided puzzle map.
+// Returns the distinct points walked by the guard, and whether the
+// guard entered an infinte loop.
+//
+// The simulation stops early if ctx is done.
+func SimulateGuardPatrol(ctx context.Context, puzzleMap *PuzzleMap) (map[grid.Point]map[grid.Direction]bool, bool, error) {
+	curPosition := puzzleMap.GuardStartPosition
+	curDir := puzzleMap.GuardStartDirection
+	seenPoints := make(map[grid.Point]map[grid.Direction]bool)
+	infiniteLoop := false
+	for steps := 1; ; steps++ {
+		if steps%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, false, aoc.Interrupted(ctx, "simulating %d steps (%d distinct positions)", steps, len(seenPoints))
+		}
+
+		if seenPoints[curPosition][curDir] {
+			infiniteLoop = true
+			break
+		}
+		if _, ok := seenPoints[curPosition]; !ok {
+			seenPoints[curPosition] = make(map[grid.Direction]bool)
+		}
+		seenPoints[curPosition][curDir] = true
+
+		nextPos := curPosition.Move(curDir)
+		nextPosRune, ok := puzzleMap.MapData.At(nextPos)
+		if !ok {
+			// Guard left map
+			break
+		} else if nextPosRune == '#' {
+			// Guard hit obstruction, turn right 90 deg
+			curDir = curDir.TurnRight()
+		} else {
+			// Guard is free to move foward
+			curPosition = nextPos
+		}
+	}
+
+	return seenPoints, infiniteLoop, nil
+}
+
+// FindAllLoopingOptionsReference attempts to find all solutions points which would
+// cause the guard to enter an infinite loop if an obstruction was placed.
+//
+// This is partially bruteforce, as it has to test every possible point
+// that the guard would normally walk in the original puzzle input, by
+// simulating the whole patrol again. It is the reference for
+// FindAllLoopingOptions in the differential tests.
+//
+// It stops early if ctx is done, reporting how many candidates were tested.
+func FindAllLoopingOptionsReference(ctx context.Context, puzzleMap *PuzzleMap) ([]grid.Point, error) {
+	// Simulate it once to get the list of points walked by the guard.
+	possibleObstructionPoints, _, err := SimulateGuardPatrol(ctx, puzzleMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Obstructions are placed on a copy of the map, so the caller's map is
+	// left untouched (and can be shared with other goroutines meanwhile).
+	mapData := puzzleMap.MapData.Clone()
+	var loopCausingObstructions []grid.Point
+	tested := 0
+	for point := range possibleObstructionPoints {
+		if ctx.Err() != nil {
+			return nil, aoc.Interrupted(ctx, "testing %d of %d candidate obstructions (%d loops found so far)",
+				tested, len(possibleObstructionPoints), len(loopCausingObstructions))
+		}
+
+		// Only try to add obstructions where there aren't any existing,
+		// and not in the original starting position of the guard.
+		isStartingPos := point == puzzleMap.GuardStartPosition
+
+		if pointRune, _ := mapData.At(point); pointRune != '#' && !isStartingPos {
+			newPuzzleMap := &PuzzleMap{
+				MapData:             mapData,
+				GuardStartPosition:  puzzleMap.GuardStartPosition,
+				GuardStartDirection: puzzleMap.GuardStartDirection,
+			}
+
+			// We add the obstruction in the copied map data, simluate, then
+			// put back the original rune. This keeps us from having to deep
+			// copy the map data for each possible solution.
+			originalRune, _ := mapData.At(point)
+			mapData.Set(point, '#')
+			_, infiniteLoop, err := SimulateGuardPatrol(ctx, newPuzzleMap)
+			mapData.Set(point, originalRune)
+			if err != nil {
+				return nil, aoc.Interrupted(ctx, "testing %d of %d candidate obstructions (%d loops found so far)",
+					tested, len(possibleObstructionPoints), len(loopCausingObstructions))
+			}
+
+			if infiniteLoop {
+				loopCausingObstructions = append(loopCausingObstructions, point)
+			}
+		}
+		tested++
+	}
+	return loopCausingObstructions, nil
+}
+
+// FindAllLoopingOptions finds all points which would cause the guard to enter
+// an infinite loop if an obstruction was placed there.
+//
+// The candidates are the points of the guard's patrol, like for
+// FindAllLoopingOptionsReference, but each is tested as the guard first
+// reaches it: up to there the patrol is the same with or without the
+// obstruction, so only the rest of it is simulated.
+//
+// It stops early if ctx is done, reporting how many candidates were tested.
+func FindAllLoopingOptions(ctx context.Context, puzzleMap *PuzzleMap) ([]grid.Point, error) {
+	mapData := puzzleMap.MapData
+	cells := mapData.Rows() * mapData.Cols()
+
+	// Both are indexed by point and direction. Turns made while testing a
+	// candidate are marked with its number, so they needn't be cleared
+	// between candidates.
+	patrolled := make([]bool, cells*len(grid.Cardinals))
+	turns := make([]int, cells*len(grid.Cardinals))
+	reached := make([]bool, cells)
+
+	var loopCausingObstructions []grid.Point
+	tested := 0
+	position, dir := puzzleMap.GuardStartPosition, puzzleMap.GuardStartDirection
+	for {
+		// The patrol itself may be a loop.
+		state := stateIndex(mapData, position, dir)
+		if patrolled[state] {
+			break
+		}
+		patrolled[state] = true
+
+		next := position.Move(dir)
+		nextRune, ok := mapData.At(next)
+		if !ok {
+			break
+		} else if nextRune == '#' {
+			dir = dir.TurnRight()
+			continue
+		}
+
+		cell := next.Row*mapData.Cols() + next.Col
+		if next != puzzleMap.GuardStartPosition && !reached[cell] {
+			if ctx.Err() != nil {
+				return nil, aoc.Interrupted(ctx, "testing %d candidate obstructions (%d loops found so far)",
+					tested, len(loopCausingObstructions))
+			}
+
+			reached[cell] = true
+			tested++
+			if patrolLoops(mapData, position, dir, next, turns, tested) {
+				loopCausingObstructions = append(loopCausingObstructions, next)
+			}
+		}
+		position = next
+	}
+	return loopCausingObstructions, nil
+}
+
+// patrolLoops reports whether the guard, from the position and direction,
+// enters a loop with an extra obstruction placed on the map. Turns are
+// recorded in turns, marked with the candidate number.
+func patrolLoops(mapData *grid.Grid[rune], position grid.Point, dir grid.Direction, obstruction grid.Point, turns []int, candidate int) bool {
+	for {
+		next := position.Move(dir)
+		nextRune, ok := mapData.At(next)
+		if !ok {
+			return false
+		}
+		if nextRune != '#' && next != obstruction {
+			position = next
+			continue
+		}
+
+		// A loop has to turn, so turning the same way at the same point twice is a loop.
+		state := stateIndex(mapData, position, dir)
+		if turns[state] == candidate {
+			return true
+		}
+		turns[state] = candidate
+		dir = dir.TurnRight()
+	}
+}
+
+// stateIndex returns the index of a point and (cardinal) direction of the map.
+func stateIndex(mapData *grid.Grid[rune], position grid.Point, dir grid.Direction) int {
+	return (position.Row*mapData.Cols()+position.Col)*len(grid.Cardinals) + int(dir)/2
+}
+
+// Solver solves day 6 and is registered with the aoc runner.
+type Solver struct{}
+
+func (Solver) Parse(r io.Reader) (*PuzzleMap, error) {
+	puzzleMap, err := ParseMap(r)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing map input: %w", err)
+	}
+	return puzzleMap, nil
+}
+
+func (Solver) Part1(ctx context.Context, puzzleMap *PuzzleMap) (aoc.Answer, error) {
+	distinctPositions, infiniteLoop, err := SimulateGuardPatrol(ctx, puzzleMap)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.IntAnswer(len(distinctPositions)).WithMeta("infinite_loop", infiniteLoop), nil
+}
+
+func (Solver) Part2(ctx context.Context, puzzleMap *PuzzleMap) (aoc.Answer, error) {
+	loopCausingObstructions, err := FindAllLoopingOptions(ctx, puzzleMap)
+	if err != nil {
+		return aoc.Answer{}, err
+	}
+	return aoc.IntAnswer(len(loopCausingObstructions)), nil
+}
+
+func init() {
+	aoc.Register[*PuzzleMap](6, Solver{})
+}
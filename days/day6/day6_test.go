@@ -0,0 +1,85 @@
+package day6
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
+	"github.com/Andoryuuta/AdventOfCode2024/difftest"
+	"github.com/Andoryuuta/AdventOfCode2024/grid"
+)
+
+func FuzzParseMap(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", "challenge_data"), 6)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		puzzleMap, err := ParseMap(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if r, ok := puzzleMap.MapData.At(puzzleMap.GuardStartPosition); !ok || r != '.' {
+			t.Fatalf("got %q at the guard's start position, expected '.'", r)
+		}
+
+		// Large maps can take a while to search for loops, which isn't what's being tested.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, _, err := SimulateGuardPatrol(ctx, puzzleMap); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("got error %v, expected nil", err)
+		}
+		if _, err := FindAllLoopingOptions(ctx, puzzleMap); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("got error %v, expected nil", err)
+		}
+	})
+}
+
+func TestFindAllLoopingOptionsDifferential(t *testing.T) {
+	loopingOptions := func(findAllLoopingOptions func(context.Context, *PuzzleMap) ([]grid.Point, error)) difftest.Func {
+		return func(input []byte) (any, error) {
+			puzzleMap, err := ParseMap(bytes.NewReader(input))
+			if err != nil {
+				return nil, err
+			}
+			options, err := findAllLoopingOptions(context.Background(), puzzleMap)
+			if err != nil {
+				return nil, err
+			}
+			// The reference finds them in map iteration order.
+			slices.SortFunc(options, func(a, b grid.Point) int {
+				if a.Row != b.Row {
+					return cmp.Compare(a.Row, b.Row)
+				}
+				return cmp.Compare(a.Col, b.Col)
+			})
+			return options, nil
+		}
+	}
+
+	difftest.Check(t, difftest.Options{Day: 6, Sizes: []int{1, 5, 10, 30}, Seeds: 50},
+		loopingOptions(FindAllLoopingOptionsReference), loopingOptions(FindAllLoopingOptions))
+}
+
+func TestFindAllLoopingOptionsLeavesMap(t *testing.T) {
+	input := "....#.....\n.........#\n..........\n..#.......\n.......#..\n..........\n.#..^.....\n........#.\n#.........\n......#...\n"
+	for _, findAllLoopingOptions := range []func(context.Context, *PuzzleMap) ([]grid.Point, error){FindAllLoopingOptionsReference, FindAllLoopingOptions} {
+		puzzleMap, err := ParseMap(bytes.NewReader([]byte(input)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		original := puzzleMap.MapData.Clone()
+		options, err := findAllLoopingOptions(context.Background(), puzzleMap)
+		if err != nil || len(options) != 6 {
+			t.Fatalf("got %d options (err: %v), expected 6", len(options), err)
+		}
+		for row := 0; row < original.Rows(); row++ {
+			if got, expected := string(puzzleMap.MapData.Row(row)), string(original.Row(row)); got != expected {
+				t.Errorf("got row %d %q after searching, expected %q", row, got, expected)
+			}
+		}
+	}
+}
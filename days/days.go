@@ -0,0 +1,14 @@
+// Package days imports every day's solution so they are registered with the
+// aoc registry. Import it for side effects:
+//
+//	import _ "github.com/Andoryuuta/AdventOfCode2024/days"
+package days
+
+import (
+	_ "github.com/Andoryuuta/AdventOfCode2024/days/day1"
+	_ "github.com/Andoryuuta/AdventOfCode2024/days/day2"
+	_ "github.com/Andoryuuta/AdventOfCode2024/days/day3"
+	_ "github.com/Andoryuuta/AdventOfCode2024/days/day4"
+	_ "github.com/Andoryuuta/AdventOfCode2024/days/day5"
+	_ "github.com/Andoryuuta/AdventOfCode2024/days/day6"
+)
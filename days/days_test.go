@@ -0,0 +1,114 @@
+package days
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/aoctest"
+	"github.com/Andoryuuta/AdventOfCode2024/manifest"
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+// TestManifestAnswers runs every registered day against each of its inputs in
+// challenge_data, and checks the answers recorded in the manifest.
+func TestManifestAnswers(t *testing.T) {
+	dataDir := filepath.Join("..", "challenge_data")
+	m, err := manifest.Load(filepath.Join(dataDir, manifest.DefaultFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, day := range aoc.Days() {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		inputs, err := manifest.InputFiles(dataDir, day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, inputPath := range inputs {
+			key, err := manifest.Key(dataDir, inputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, part := range d.Parts() {
+				expected, ok := m.Expected(key, part)
+				if !ok {
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s/part%d", key, part), func(t *testing.T) {
+					data, err := vault.ReadFile(inputPath)
+					if errors.Is(err, vault.ErrNoPassphrase) {
+						t.Skip(err)
+					} else if err != nil {
+						t.Fatal(err)
+					}
+
+					answer, err := d.Solve(context.Background(), part, bytes.NewReader(data))
+					if err != nil {
+						t.Fatalf("got error %v, expected nil", err)
+					}
+					if answer.String() != expected {
+						t.Errorf("got %v, expected %v", answer, expected)
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestGolden compares the output of every registered day with the golden
+// (.expected) files in challenge_data, and their answers with the manifest.
+// Run with -update to rewrite the golden files.
+func TestGolden(t *testing.T) {
+	aoctest.Golden(t, filepath.Join("..", "challenge_data"))
+}
+
+// BenchmarkDays benchmarks parsing and solving each part of every registered
+// day against each of its inputs in challenge_data.
+func BenchmarkDays(b *testing.B) {
+	dataDir := filepath.Join("..", "challenge_data")
+	for _, day := range aoc.Days() {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		inputs, err := manifest.InputFiles(dataDir, day)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, inputPath := range inputs {
+			data, err := vault.ReadFile(inputPath)
+			if errors.Is(err, vault.ErrNoPassphrase) {
+				continue
+			} else if err != nil {
+				b.Fatal(err)
+			}
+			key, err := manifest.Key(dataDir, inputPath)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for _, part := range d.Parts() {
+				b.Run(fmt.Sprintf("%s/part%d", key, part), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						if _, err := d.Solve(context.Background(), part, bytes.NewReader(data)); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package diag turns input parse errors into diagnostics: the offending line
+// with a caret under the bad column, and suggestions for the likely mistake.
+// Diagnostics can be rendered for a terminal, or encoded as JSON for editors.
+package diag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// Diagnostic describes a problem at a position in an input file.
+type Diagnostic struct {
+	File        string   `json:"file,omitempty"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column,omitempty"` // 1-based, or 0 for the whole line
+	Severity    string   `json:"severity"`
+	Message     string   `json:"message"`
+	Source      string   `json:"source"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// FromError returns the diagnostic for the first *parse.Error wrapped by err,
+// and false if err doesn't carry a position.
+func FromError(err error) (*Diagnostic, bool) {
+	var parseErr *parse.Error
+	if !errors.As(err, &parseErr) {
+		return nil, false
+	}
+
+	return &Diagnostic{
+		File:        parseErr.File,
+		Line:        parseErr.Line,
+		Column:      parseErr.Column,
+		Severity:    "error",
+		Message:     parseErr.Msg,
+		Source:      parseErr.Text,
+		Suggestions: suggest(parseErr),
+	}, true
+}
+
+// suggest guesses the likely cause of a parse error from the offending line.
+func suggest(e *parse.Error) []string {
+	var suggestions []string
+	add := func(format string, args ...any) {
+		suggestions = append(suggestions, fmt.Sprintf(format, args...))
+	}
+
+	if e.Text == "" {
+		add("the line is empty; remove it")
+	}
+
+	if e.Separator != "" && e.Text != "" {
+		switch {
+		case strings.Contains(e.Text, "\t") && !strings.Contains(e.Separator, "\t"):
+			add("the line contains a tab; fields must be separated by %s", describeSeparator(e.Separator))
+		case strings.Count(e.Separator, " ") > 1 && strings.Contains(e.Text, " ") && !strings.Contains(e.Text, e.Separator):
+			add("fields are separated by single spaces; they must be separated by %s", describeSeparator(e.Separator))
+		default:
+			add("fields must be separated by %s", describeSeparator(e.Separator))
+		}
+	}
+
+	if e.Text != "" && e.Column > 0 {
+		field := e.Field
+		switch {
+		case field == "" && e.Separator == "":
+			add("the value is missing; check for repeated or trailing separators")
+		case strings.HasPrefix(field, "-") || strings.HasPrefix(field, "+"):
+			add("signed numbers aren't allowed; remove the %q sign", field[:1])
+		case len(field) > 2 && strings.EqualFold(field[:2], "0x"):
+			if v, err := strconv.ParseUint(field[2:], 16, 64); err == nil {
+				add("hexadecimal numbers aren't allowed; use the decimal value %d", v)
+			} else {
+				add("hexadecimal numbers aren't allowed; use decimal")
+			}
+		case errors.Is(e.Err, strconv.ErrRange):
+			add("the number is too large")
+		case strings.TrimSpace(field) != field:
+			add("the value has leading or trailing whitespace")
+		}
+	}
+
+	return suggestions
+}
+
+func describeSeparator(sep string) string {
+	switch {
+	case sep == " ":
+		return "a single space"
+	case strings.Trim(sep, " ") == "":
+		return fmt.Sprintf("%d spaces", len(sep))
+	case sep == "\t":
+		return "a tab"
+	}
+	return strconv.Quote(sep)
+}
+
+// Render formats the diagnostic for a terminal, e.g.
+//
+//	input:3:1: error: expected unsigned decimal integer, got "-1"
+//	  3 | -1   2
+//	    | ^
+//	  help: signed numbers aren't allowed; remove the "-" sign
+func (d *Diagnostic) Render() string {
+	var sb strings.Builder
+
+	if d.File != "" {
+		sb.WriteString(d.File)
+		sb.WriteByte(':')
+	}
+	fmt.Fprintf(&sb, "%d:", d.Line)
+	if d.Column > 0 {
+		fmt.Fprintf(&sb, "%d:", d.Column)
+	}
+	fmt.Fprintf(&sb, " %s: %s\n", d.Severity, d.Message)
+
+	// Tabs are shown as a single visible rune so the caret stays aligned with the column.
+	source := strings.ReplaceAll(d.Source, "\t", "→")
+
+	gutter := strconv.Itoa(d.Line)
+	padding := strings.Repeat(" ", len(gutter))
+	fmt.Fprintf(&sb, "  %s | %s\n", gutter, source)
+
+	var marker string
+	if d.Column > 0 {
+		marker = strings.Repeat(" ", d.Column-1) + "^"
+	} else {
+		marker = strings.Repeat("~", max(utf8.RuneCountInString(source), 1))
+	}
+	fmt.Fprintf(&sb, "  %s | %s\n", padding, marker)
+
+	for _, suggestion := range d.Suggestions {
+		fmt.Fprintf(&sb, "  help: %s\n", suggestion)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package diag
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// parseLocationLine parses a line the way day 1 does: two unsigned integers
+// separated by three spaces.
+func parseLocationLine(line parse.Line) error {
+	fields, err := line.Split("   ", 2)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if _, err := line.Uint(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFromError(t *testing.T) {
+	var tests = []struct {
+		name               string
+		input              string
+		expectedLine       int
+		expectedColumn     int
+		expectedSuggestion string
+	}{
+		{"tab instead of three spaces", "1   2\n3\t4", 2, 0, "the line contains a tab"},
+		{"single space instead of three spaces", "1 6", 1, 0, "separated by single spaces"},
+		{"signed number", "1   2\n3   -4", 2, 5, "signed numbers aren't allowed"},
+		{"hexadecimal", "0x1F   2", 1, 1, "use the decimal value 31"},
+		{"empty line", "1   2\n\n5   6", 2, 0, "the line is empty"},
+		{"too many separators", "1   2   3", 1, 6, "fields must be separated by 3 spaces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			lines := parse.NewLines(strings.NewReader(tt.input))
+			for err == nil && lines.Next() {
+				err = parseLocationLine(lines.Line())
+			}
+
+			d, ok := FromError(errors.Join(errors.New("error parsing location list"), err))
+			if !ok {
+				t.Fatalf("got no diagnostic for error %v", err)
+			}
+			if d.Line != tt.expectedLine || d.Column != tt.expectedColumn {
+				t.Errorf("got position %d:%d, expected %d:%d", d.Line, d.Column, tt.expectedLine, tt.expectedColumn)
+			}
+			if !strings.Contains(strings.Join(d.Suggestions, "\n"), tt.expectedSuggestion) {
+				t.Errorf("got suggestions %q, expected one containing %q", d.Suggestions, tt.expectedSuggestion)
+			}
+		})
+	}
+
+	if _, ok := FromError(errors.New("no position")); ok {
+		t.Errorf("got diagnostic for an error without a position")
+	}
+}
+
+func TestRender(t *testing.T) {
+	d := &Diagnostic{
+		File:        "input",
+		Line:        12,
+		Column:      5,
+		Severity:    "error",
+		Message:     `expected unsigned decimal integer, got "-4"`,
+		Source:      "3\t  -4",
+		Suggestions: []string{`signed numbers aren't allowed; remove the "-" sign`},
+	}
+
+	expected := `input:12:5: error: expected unsigned decimal integer, got "-4"
+  12 | 3→  -4
+     |     ^
+  help: signed numbers aren't allowed; remove the "-" sign
+`
+	if got := d.Render(); got != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Diagnostic
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Column != 5 || decoded.Source != d.Source {
+		t.Errorf("got %+v (err: %v) after JSON round trip", decoded, err)
+	}
+}
@@ -0,0 +1,191 @@
+// Package difftest compares an optimised implementation with a (usually brute
+// force) reference one, on inputs generated by the gen package. The first
+// input they disagree on is minimised by delta debugging, so it's small
+// enough to debug by hand:
+//
+//	func TestIsReportSafeDifferential(t *testing.T) {
+//		difftest.Check(t, difftest.Options{Day: 2, Sizes: []int{1, 5, 20}, Seeds: 50}, reference, optimised)
+//	}
+//
+// Run more seeds than a test's own with -difftest.seeds.
+package difftest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/gen"
+)
+
+var seeds = flag.Int("difftest.seeds", 0, "number of seeds to generate inputs from, for each size (default: each test's own)")
+
+// Func computes a result from a puzzle input. Results are compared by their
+// formatting with %v.
+type Func func(input []byte) (any, error)
+
+// Options describes the generated inputs to compare the implementations on.
+type Options struct {
+	Day   int   // day whose generator is used
+	Sizes []int // sizes of input to generate, smallest first
+	Seeds int   // number of seeds, from 1, for each size
+}
+
+// Disagreement is an input the implementations disagree on.
+type Disagreement struct {
+	Seed      int64
+	Size      int
+	Generated []byte // input the disagreement was found on
+	Input     []byte // the minimised input
+
+	// Results on the minimised input (or their errors, or panics).
+	Reference string
+	Optimised string
+}
+
+func (d *Disagreement) Error() string {
+	return fmt.Sprintf("implementations disagree on input generated with seed %d, size %d (%d bytes), minimised to:\n%s\nreference: %s\noptimised: %s",
+		d.Seed, d.Size, len(d.Generated), quoteLines(d.Input), d.Reference, d.Optimised)
+}
+
+// quoteLines formats an input one quoted line at a time, so whitespace is visible.
+func quoteLines(input []byte) string {
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(string(input), "\n") {
+		if line != "" {
+			fmt.Fprintf(&sb, "  %s\n", strconv.Quote(line))
+		}
+	}
+	return sb.String()
+}
+
+// Compare runs both implementations on the generated inputs, and returns the
+// first disagreement, or nil if they always agree.
+func Compare(opts Options, reference, optimised Func) (*Disagreement, error) {
+	g, err := gen.Lookup(opts.Day)
+	if err != nil {
+		return nil, err
+	}
+	if *seeds > 0 {
+		opts.Seeds = *seeds
+	}
+
+	disagree := func(input []byte) bool {
+		return outcome(reference, input) != outcome(optimised, input)
+	}
+	for _, size := range opts.Sizes {
+		for seed := int64(1); seed <= int64(opts.Seeds); seed++ {
+			input, err := g.Generate(seed, size)
+			if err != nil {
+				return nil, err
+			}
+			if !disagree(input) {
+				continue
+			}
+
+			minimised := Minimise(input, disagree)
+			return &Disagreement{
+				Seed:      seed,
+				Size:      size,
+				Generated: input,
+				Input:     minimised,
+				Reference: outcome(reference, minimised),
+				Optimised: outcome(optimised, minimised),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Check fails the test with the first disagreement found by Compare.
+func Check(t testing.TB, opts Options, reference, optimised Func) {
+	t.Helper()
+	d, err := Compare(opts, reference, optimised)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d != nil {
+		t.Fatal(d)
+	}
+}
+
+// outcome runs the function on the input, describing its result, error or panic.
+func outcome(f Func, input []byte) (result string) {
+	defer func() {
+		if p := recover(); p != nil {
+			result = fmt.Sprintf("panic: %v", p)
+		}
+	}()
+
+	v, err := f(input)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Minimise returns a smaller input which is still interesting, found by delta
+// debugging: first removing lines, then fields (each with the space or comma
+// before it), then single characters. The input must be interesting.
+func Minimise(input []byte, interesting func([]byte) bool) []byte {
+	lines := bytes.SplitAfter(input, []byte("\n"))
+	input = bytes.Join(ddmin(lines, interesting), nil)
+
+	input = bytes.Join(ddmin(splitFields(input), interesting), nil)
+
+	chars := bytes.Split(input, nil)
+	return bytes.Join(ddmin(chars, interesting), nil)
+}
+
+// splitFields splits the input before each space, comma and line.
+func splitFields(input []byte) [][]byte {
+	var fields [][]byte
+	start := 0
+	for i, c := range input {
+		if i > start && (c == ' ' || c == ',' || c == '\n') {
+			fields = append(fields, input[start:i])
+			start = i
+		}
+	}
+	return append(fields, input[start:])
+}
+
+// ddmin is Zeller's minimising delta debugging algorithm: it removes ever
+// smaller chunks of the parts while the rest is still interesting, until no
+// single part can be removed.
+func ddmin(parts [][]byte, interesting func([]byte) bool) [][]byte {
+	test := func(parts [][]byte) bool {
+		return interesting(bytes.Join(parts, nil))
+	}
+
+	n := 2
+	for len(parts) >= 2 {
+		chunk := (len(parts) + n - 1) / n
+		reduced := false
+
+		// Try each chunk on its own, then the rest without each chunk.
+		for start := 0; start < len(parts) && !reduced; start += chunk {
+			subset := parts[start:min(start+chunk, len(parts))]
+			if test(subset) {
+				parts, n, reduced = subset, 2, true
+			}
+		}
+		for start := 0; start < len(parts) && !reduced; start += chunk {
+			complement := append(append([][]byte{}, parts[:start]...), parts[min(start+chunk, len(parts)):]...)
+			if test(complement) {
+				parts, n, reduced = complement, max(n-1, 2), true
+			}
+		}
+
+		if !reduced {
+			if n >= len(parts) {
+				break
+			}
+			n = min(2*n, len(parts))
+		}
+	}
+	return parts
+}
@@ -0,0 +1,106 @@
+package difftest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMinimise(t *testing.T) {
+	var tests = []struct {
+		name        string
+		input       string
+		interesting func(input string) bool
+		expected    string
+	}{
+		{
+			"single line",
+			"a\nb\nbad\nc\n",
+			func(input string) bool { return strings.Contains(input, "bad") },
+			"bad",
+		},
+		{
+			"two lines",
+			"a\nx\nb\nc\ny\n",
+			func(input string) bool { return strings.Contains(input, "x") && strings.Contains(input, "y") },
+			"xy",
+		},
+		{
+			"fields",
+			"1 2 3 40 5\n6,70,8\n",
+			func(input string) bool {
+				return strings.Contains(input, "40") && strings.Contains(input, "70") && !strings.Contains(input, "  ")
+			},
+			"4070",
+		},
+		{
+			"whole input",
+			"abc",
+			func(input string) bool { return input == "abc" },
+			"abc",
+		},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			interesting := func(input []byte) bool { return tt.interesting(string(input)) }
+			result := Minimise([]byte(tt.input), interesting)
+			if string(result) != tt.expected {
+				t.Errorf("got %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// lineCount counts the lines of the input, wrongly if one contains a 7.
+	lineCount := func(buggy bool) Func {
+		return func(input []byte) (any, error) {
+			count := 0
+			for _, line := range bytes.Split(bytes.TrimSpace(input), []byte("\n")) {
+				if buggy && bytes.Contains(line, []byte("7")) {
+					continue
+				}
+				count++
+			}
+			return count, nil
+		}
+	}
+	opts := Options{Day: 1, Sizes: []int{1, 10}, Seeds: 10}
+
+	d, err := Compare(opts, lineCount(false), lineCount(false))
+	if err != nil || d != nil {
+		t.Errorf("got %v (err: %v), expected no disagreement", d, err)
+	}
+
+	d, err = Compare(opts, lineCount(false), lineCount(true))
+	if err != nil || d == nil {
+		t.Fatalf("got %v (err: %v), expected a disagreement", d, err)
+	}
+	if string(d.Input) != "7" {
+		t.Errorf("got minimised input %q, expected %q", d.Input, "7")
+	}
+	if d.Reference != "1" || d.Optimised != "0" {
+		t.Errorf("got results %s and %s, expected 1 and 0", d.Reference, d.Optimised)
+	}
+}
+
+func TestComparePanics(t *testing.T) {
+	panics := func(input []byte) (any, error) {
+		if bytes.Contains(input, []byte("\n")) {
+			panic("unexpected newline")
+		}
+		return 0, nil
+	}
+	zero := func(input []byte) (any, error) { return 0, nil }
+
+	d, err := Compare(Options{Day: 1, Sizes: []int{2}, Seeds: 1}, zero, panics)
+	if err != nil || d == nil {
+		t.Fatalf("got %v (err: %v), expected a disagreement", d, err)
+	}
+	if d.Optimised != "panic: unexpected newline" || string(d.Input) != "\n" {
+		t.Errorf("got %q on %q, expected the panic on a newline", d.Optimised, d.Input)
+	}
+}
@@ -0,0 +1,199 @@
+// Package external runs solvers written in other languages, declared in a
+// config file, so their answers can be compared with the Go solutions:
+//
+//	{
+//		"solvers": [
+//			{"name": "aoc", "day": 6, "command": "go run ./cmd/aoc run 6 --no-cache {input}"}
+//		]
+//	}
+//
+// (That solver is just aoc run itself; a real one would run a solution kept
+// outside the repository.) The config is local to each checkout, and isn't
+// committed.
+//
+// A command is split on spaces (there's no shell quoting), and runs in the
+// config file's directory. "{input}" is replaced with the input file's path.
+//
+// If the command also contains "{part}", it's run once for each part, with
+// "{part}" replaced by the part number, and the answer is the last word of
+// its output. Otherwise it's run once, and must print a line for each part
+// such as "Part 1: 18" (or "Day 4, part 1: 18", like aoc run).
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFile is the name of the config file in the repository root.
+const DefaultFile = "external.json"
+
+// Solver is an external solver command.
+type Solver struct {
+	Name    string `json:"name"`
+	Day     int    `json:"day"`
+	Parts   []int  `json:"parts,omitempty"` // parts it solves (default: both)
+	Command string `json:"command"`
+
+	// Dir is the directory the command runs in: the config file's.
+	Dir string `json:"-"`
+}
+
+// Config lists the external solvers.
+type Config struct {
+	Solvers []Solver `json:"solvers"`
+}
+
+// Load reads and checks a config file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid external solver config %s: %v", path, err)
+	}
+	for i := range config.Solvers {
+		s := &config.Solvers[i]
+		if err := s.check(); err != nil {
+			return nil, fmt.Errorf("invalid external solver config %s: solver %d: %v", path, i+1, err)
+		}
+		if len(s.Parts) == 0 {
+			s.Parts = []int{1, 2}
+		}
+		s.Dir = filepath.Dir(path)
+	}
+	return &config, nil
+}
+
+func (s *Solver) check() error {
+	switch {
+	case s.Name == "":
+		return errors.New("missing name")
+	case s.Day < 1 || s.Day > 25:
+		return fmt.Errorf("invalid day %d", s.Day)
+	case len(strings.Fields(s.Command)) == 0:
+		return errors.New("missing command")
+	case !strings.Contains(s.Command, "{input}"):
+		return errors.New(`command doesn't take the input file ("{input}")`)
+	}
+	for _, part := range s.Parts {
+		if part != 1 && part != 2 {
+			return fmt.Errorf("invalid part %d", part)
+		}
+	}
+	return nil
+}
+
+// ForDay returns the solvers for a day.
+func (c *Config) ForDay(day int) []Solver {
+	var solvers []Solver
+	for _, s := range c.Solvers {
+		if s.Day == day {
+			solvers = append(solvers, s)
+		}
+	}
+	return solvers
+}
+
+// Solve runs the solver on an input file, and returns the answer of each of
+// its parts.
+func (s Solver) Solve(ctx context.Context, inputPath string) (map[int]string, error) {
+	inputPath, err := filepath.Abs(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := map[int]string{}
+	if !strings.Contains(s.Command, "{part}") {
+		output, err := s.run(ctx, inputPath, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range s.Parts {
+			answer, ok := partAnswer(output, part)
+			if !ok {
+				return nil, fmt.Errorf("%s printed no answer for part %d", s.Name, part)
+			}
+			answers[part] = answer
+		}
+		return answers, nil
+	}
+
+	for _, part := range s.Parts {
+		output, err := s.run(ctx, inputPath, part)
+		if err != nil {
+			return nil, err
+		}
+		words := strings.Fields(output)
+		if len(words) == 0 {
+			return nil, fmt.Errorf("%s printed no answer for part %d", s.Name, part)
+		}
+		answers[part] = words[len(words)-1]
+	}
+	return answers, nil
+}
+
+// waitDelay is how long to wait for a killed command's output to be closed.
+const waitDelay = time.Second
+
+// run runs the command, and returns its output.
+func (s Solver) run(ctx context.Context, inputPath string, part int) (string, error) {
+	args := strings.Fields(s.Command)
+	for i, arg := range args {
+		arg = strings.ReplaceAll(arg, "{input}", inputPath)
+		args[i] = strings.ReplaceAll(arg, "{part}", strconv.Itoa(part))
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = s.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Stop waiting for the output of any processes the command started, once it's killed.
+	cmd.WaitDelay = waitDelay
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		if msg := lastLine(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %v: %s", s.Name, err, msg)
+		}
+		return "", fmt.Errorf("%s: %v", s.Name, err)
+	}
+	return stdout.String(), nil
+}
+
+// partLine matches a line of output with a part's answer, such as "Part 1: 18".
+var partLine = regexp.MustCompile(`(?i)\bpart\s*(\d+)\b[^\d\n-]*(-?\d+)`)
+
+// partAnswer returns the answer of a part from the output, the last if there
+// are several.
+func partAnswer(output string, part int) (string, bool) {
+	answer, ok := "", false
+	for _, match := range partLine.FindAllStringSubmatch(output, -1) {
+		if match[1] == strconv.Itoa(part) {
+			answer, ok = match[2], true
+		}
+	}
+	return answer, ok
+}
+
+// lastLine returns the last non-empty line of the text, which is usually the
+// most useful line of an error message or stack trace.
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,174 @@
+package external
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes a config and the given shell scripts to a temporary
+// directory, and returns the config's path.
+func writeConfig(t *testing.T, config string, scripts map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(dir, DefaultFile)
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `{"solvers": [
+		{"name": "python", "day": 4, "command": "python3 day4.py {input}"},
+		{"name": "awk", "day": 1, "parts": [2], "command": "awk -f day1.awk {input}"}
+	]}`, nil)
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if len(config.Solvers) != 2 {
+		t.Fatalf("got %d solvers, expected 2", len(config.Solvers))
+	}
+	if parts := config.Solvers[0].Parts; !reflect.DeepEqual(parts, []int{1, 2}) {
+		t.Errorf("got parts %v, expected both parts by default", parts)
+	}
+	if dir := config.Solvers[0].Dir; dir != filepath.Dir(path) {
+		t.Errorf("got dir %q, expected the config's directory %q", dir, filepath.Dir(path))
+	}
+	if solvers := config.ForDay(1); len(solvers) != 1 || solvers[0].Name != "awk" {
+		t.Errorf("got %+v for day 1, expected the awk solver", solvers)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	var tests = []struct {
+		config   string
+		expected string
+	}{
+		{`{"solvers": [{"day": 4, "command": "x {input}"}]}`, "missing name"},
+		{`{"solvers": [{"name": "x", "day": 26, "command": "x {input}"}]}`, "invalid day 26"},
+		{`{"solvers": [{"name": "x", "day": 4, "command": " "}]}`, "missing command"},
+		{`{"solvers": [{"name": "x", "day": 4, "command": "x input"}]}`, "doesn't take the input file"},
+		{`{"solvers": [{"name": "x", "day": 4, "parts": [3], "command": "x {input}"}]}`, "invalid part 3"},
+		{`{"solvers": {}}`, "invalid external solver config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.config, nil))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("got error %v, expected one containing %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	var tests = []struct {
+		name     string
+		command  string
+		script   string
+		expected map[int]string
+		err      string
+	}{
+		{
+			"answer lines",
+			"sh solver.sh {input}",
+			"echo 'reading input'\necho 'Part 1: 18'\necho 'part 2 answer = -9'\n",
+			map[int]string{1: "18", 2: "-9"},
+			"",
+		},
+		{
+			"aoc run output",
+			"sh solver.sh {input}",
+			"echo 'Day 4, part 1: 18'\necho 'Day 4, part 2: 9 (extra: true)'\n",
+			map[int]string{1: "18", 2: "9"},
+			"",
+		},
+		{
+			"run for each part",
+			"sh solver.sh {input} {part}",
+			"echo 'computing...'\necho \"answer: $(($2 * 10))\"\n",
+			map[int]string{1: "10", 2: "20"},
+			"",
+		},
+		{
+			"reads the input",
+			"sh solver.sh {input} {part}",
+			"wc -l < \"$1\"\n",
+			map[int]string{1: "3", 2: "3"},
+			"",
+		},
+		{
+			"missing part",
+			"sh solver.sh {input}",
+			"echo 'Part 1: 18'\n",
+			nil,
+			"printed no answer for part 2",
+		},
+		{
+			"failure shows the last line of stderr",
+			"sh solver.sh {input}",
+			"echo 'Traceback...' >&2\necho 'ValueError: bad input' >&2\nexit 1\n",
+			nil,
+			"test: exit status 1: ValueError: bad input",
+		},
+	}
+
+	input := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(input, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, `{"solvers": [{"name": "test", "day": 4, "command": "`+tt.command+`"}]}`,
+				map[string]string{"solver.sh": tt.script})
+			config, err := Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			answers, err := config.Solvers[0].Solve(context.Background(), input)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("got error %v, expected one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if !reflect.DeepEqual(answers, tt.expected) {
+				t.Errorf("got %v, expected %v", answers, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSolveTimeout(t *testing.T) {
+	path := writeConfig(t, `{"solvers": [{"name": "slow", "day": 4, "command": "sh solver.sh {input}"}]}`,
+		map[string]string{"solver.sh": "sleep 10\n"})
+	config, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = config.Solvers[0].Solve(ctx, path)
+	if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Errorf("got error %v, expected the deadline to be exceeded", err)
+	}
+}
@@ -0,0 +1,27 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	register(Generator{Day: 1, DefaultSize: 1000, Unit: "pairs", generate: locationLists})
+}
+
+// locationLists generates pairs of five digit location IDs. Some of the right
+// list's IDs are taken from the left list, so the similarity score isn't zero.
+func locationLists(r *rand.Rand, size int, w *bytes.Buffer) {
+	left := make([]int, size)
+	for i := range left {
+		left[i] = 10000 + r.Intn(90000)
+	}
+	for _, l := range left {
+		right := 10000 + r.Intn(90000)
+		if chance(r, 0.3) {
+			right = pick(r, left)
+		}
+		fmt.Fprintf(w, "%d   %d\n", l, right)
+	}
+}
@@ -0,0 +1,44 @@
+package gen
+
+import (
+	"bytes"
+	"math/rand"
+	"strconv"
+)
+
+func init() {
+	register(Generator{Day: 2, DefaultSize: 1000, Unit: "reports", generate: reports})
+}
+
+// reports generates reports of 5 to 8 levels which steadily increase or
+// decrease. About half of them have a fault: a level which repeats the last
+// one, jumps too far, or goes the wrong way.
+func reports(r *rand.Rand, size int, w *bytes.Buffer) {
+	for i := 0; i < size; i++ {
+		levels := 5 + r.Intn(4)
+
+		// Start far enough from zero that a step the wrong way, or 8 levels
+		// of the largest steps, can't go below it.
+		level, sign := 4+r.Intn(20), 1
+		if chance(r, 0.5) {
+			level, sign = 70+r.Intn(30), -1
+		}
+		fault := -1
+		if chance(r, 0.5) {
+			fault = 1 + r.Intn(levels-1)
+		}
+
+		for j := 0; j < levels; j++ {
+			if j > 0 {
+				step := 1 + r.Intn(3)
+				if j == fault {
+					step = pick(r, []int{0, 4 + r.Intn(3), -step})
+				}
+				level += sign * step
+				w.WriteByte(' ')
+			}
+			w.WriteString(strconv.Itoa(level))
+		}
+		w.WriteByte('\n')
+	}
+}
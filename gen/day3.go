@@ -0,0 +1,41 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+)
+
+func init() {
+	register(Generator{Day: 3, DefaultSize: 750, Unit: "mul instructions", generate: corruptedMemory})
+}
+
+// nearMisses are corrupted instructions which must not be matched.
+var nearMisses = []string{"mul(%d,%d]", "mul[%d,%d)", "mul( %d,%d)", "mul(%d*%d)", "mul(%d,%d", "mul (%d,%d)", "mul(%d,,%d)"}
+
+// noise is the junk between instructions, including parts of them.
+const noise = "!@#$%^&*()[]{}<>,;:'+-/?~ _muldont'"
+
+// corruptedMemory generates lines of corrupted memory holding the given number
+// of mul instructions, between junk, near misses and do()/don't() instructions.
+func corruptedMemory(r *rand.Rand, size int, w *bytes.Buffer) {
+	for i := 0; i < size; i++ {
+		for n := r.Intn(8); n > 0; n-- {
+			w.WriteByte(noise[r.Intn(len(noise))])
+		}
+		switch {
+		case chance(r, 0.2):
+			fmt.Fprintf(w, pick(r, nearMisses), 1+r.Intn(999), 1+r.Intn(999))
+		case chance(r, 0.1):
+			w.WriteString("do()")
+		case chance(r, 0.1):
+			w.WriteString("don't()")
+		}
+		fmt.Fprintf(w, "mul(%d,%d)", 1+r.Intn(999), 1+r.Intn(999))
+
+		// A real input is about six lines.
+		if (i+1)%125 == 0 || i == size-1 {
+			w.WriteByte('\n')
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gen
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+func init() {
+	register(Generator{Day: 4, DefaultSize: 140, Unit: "rows and columns", generate: wordSearch})
+}
+
+// wordSearch generates a square grid of the letters of XMAS, with extra
+// XMASes written in every direction and extra crossed MASes, which would be
+// rare in random letters.
+func wordSearch(r *rand.Rand, size int, w *bytes.Buffer) {
+	const word = "XMAS"
+
+	grid := make([][]byte, size)
+	for row := range grid {
+		grid[row] = make([]byte, size)
+		for col := range grid[row] {
+			grid[row][col] = word[r.Intn(len(word))]
+		}
+	}
+
+	// write writes the word from (row, col) in the direction, if it fits.
+	write := func(word string, row, col, dRow, dCol int) {
+		endRow, endCol := row+dRow*(len(word)-1), col+dCol*(len(word)-1)
+		for _, i := range []int{row, col, endRow, endCol} {
+			if i < 0 || i >= size {
+				return
+			}
+		}
+		for i := range word {
+			grid[row+dRow*i][col+dCol*i] = word[i]
+		}
+	}
+
+	for n := size * size / 16; n > 0; n-- {
+		dRow, dCol := r.Intn(3)-1, r.Intn(3)-1
+		if dRow == 0 && dCol == 0 {
+			continue
+		}
+		write(word, r.Intn(size), r.Intn(size), dRow, dCol)
+	}
+
+	for n := size * size / 16; n > 0; n-- {
+		row, col := r.Intn(size), r.Intn(size)
+		write(pick(r, []string{"MAS", "SAM"}), row, col, 1, 1)
+		write(pick(r, []string{"MAS", "SAM"}), row, col+2, 1, -1)
+	}
+
+	for _, row := range grid {
+		w.Write(row)
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,54 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+func init() {
+	register(Generator{Day: 5, DefaultSize: 200, Unit: "updates", generate: printQueue})
+}
+
+// printQueue generates ordering rules between every pair of 49 two digit
+// pages, followed by updates of 5 to 23 distinct pages. The rules follow a
+// single order of the pages, so every update can be corrected, and about half
+// of the updates are already in order.
+func printQueue(r *rand.Rand, size int, w *bytes.Buffer) {
+	const pageCount = 49
+
+	var pages []int
+	for _, i := range r.Perm(90)[:pageCount] {
+		pages = append(pages, 10+i)
+	}
+
+	var rules []string
+	for i := range pages {
+		for j := i + 1; j < len(pages); j++ {
+			rules = append(rules, fmt.Sprintf("%d|%d", pages[i], pages[j]))
+		}
+	}
+	r.Shuffle(len(rules), func(i, j int) { rules[i], rules[j] = rules[j], rules[i] })
+	for _, rule := range rules {
+		w.WriteString(rule)
+		w.WriteByte('\n')
+	}
+	w.WriteByte('\n')
+
+	for i := 0; i < size; i++ {
+		// Indexes into pages, so sorting them puts the update in order.
+		update := r.Perm(pageCount)[:5+2*r.Intn(10)]
+		if chance(r, 0.5) {
+			sort.Ints(update)
+		}
+		for j, page := range update {
+			if j > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(strconv.Itoa(pages[page]))
+		}
+		w.WriteByte('\n')
+	}
+}
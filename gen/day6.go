@@ -0,0 +1,114 @@
+package gen
+
+import (
+	"bytes"
+	"math/rand"
+)
+
+func init() {
+	register(Generator{Day: 6, DefaultSize: 130, Unit: "rows and columns", generate: guardMap})
+}
+
+// guardMap generates a square map with the guard facing up, on a long patrol
+// which leaves the map, like in a real input. The patrol is built by walking
+// the guard a random distance and putting an obstruction in its way, over
+// and over; then more obstructions are scattered over about 5% of the rest of
+// the map.
+func guardMap(r *rand.Rand, size int, w *bytes.Buffer) {
+	inside := func(row, col int) bool {
+		return row >= 0 && row < size && col >= 0 && col < size
+	}
+
+	for {
+		// walked holds the directions the guard has walked through each
+		// point in. Walking through a point in the same direction twice
+		// would be a loop.
+		grid := make([][]byte, size)
+		walked := make([][]uint8, size)
+		for row := range grid {
+			grid[row] = bytes.Repeat([]byte{'.'}, size)
+			walked[row] = make([]uint8, size)
+		}
+
+		guardRow, guardCol := r.Intn(size), r.Intn(size)
+		row, col, dRow, dCol := guardRow, guardCol, -1, 0
+		walked[row][col] |= direction(dRow, dCol)
+		for turns := 0; turns < 2*size; turns++ {
+			// How far the guard could walk before an obstruction, without
+			// putting it where the guard has already been, or walking or
+			// turning into a loop.
+			var choices []int
+			for steps := 1; inside(row+dRow*(steps+1), col+dCol*(steps+1)); steps++ {
+				stepRow, stepCol := row+dRow*steps, col+dCol*steps
+				if grid[stepRow][stepCol] == '#' || walked[stepRow][stepCol]&direction(dRow, dCol) != 0 {
+					break
+				}
+				if walked[stepRow+dRow][stepCol+dCol] == 0 && walked[stepRow][stepCol]&direction(dCol, -dRow) == 0 {
+					choices = append(choices, steps)
+				}
+			}
+			if len(choices) == 0 {
+				break
+			}
+
+			for steps := pick(r, choices); steps > 0; steps-- {
+				row, col = row+dRow, col+dCol
+				walked[row][col] |= direction(dRow, dCol)
+			}
+			grid[row+dRow][col+dCol] = '#'
+			dRow, dCol = dCol, -dRow
+			walked[row][col] |= direction(dRow, dCol)
+		}
+
+		for row := range grid {
+			for col := range grid[row] {
+				if walked[row][col] == 0 && chance(r, 0.05) {
+					grid[row][col] = '#'
+				}
+			}
+		}
+
+		// Obstructions could still turn the guard into a loop on its way out of the map.
+		if !leavesMap(grid, guardRow, guardCol) {
+			continue
+		}
+		grid[guardRow][guardCol] = '^'
+
+		for _, row := range grid {
+			w.Write(row)
+			w.WriteByte('\n')
+		}
+		return
+	}
+}
+
+// direction returns a bit for each direction the guard can face.
+func direction(dRow, dCol int) uint8 {
+	return 1 << ((dRow+1)*3 + dCol + 1)
+}
+
+// leavesMap reports whether the guard leaves the map from (row, col), facing
+// up, rather than walking in a loop.
+func leavesMap(grid [][]byte, row, col int) bool {
+	size := len(grid)
+	dRow, dCol := -1, 0
+	turns := map[[4]int]bool{}
+	for {
+		nextRow, nextCol := row+dRow, col+dCol
+		if nextRow < 0 || nextRow >= size || nextCol < 0 || nextCol >= size {
+			return true
+		}
+		if grid[nextRow][nextCol] != '#' {
+			row, col = nextRow, nextCol
+			continue
+		}
+
+		// The guard is in a loop once it turns at the same place, the same way, twice.
+		turn := [4]int{row, col, dRow, dCol}
+		if turns[turn] {
+			return false
+		}
+		turns[turn] = true
+		dRow, dCol = dCol, -dRow
+	}
+}
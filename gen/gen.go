@@ -0,0 +1,80 @@
+// Package gen generates puzzle inputs, to stress-test the solutions on large
+// inputs without sharing real ones (which Advent of Code asks not to be
+// published).
+//
+// Generators are deterministic: the same seed and size always give the same
+// input. Every generated input parses with the day's parser.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Generator generates inputs for a day.
+type Generator struct {
+	Day int
+
+	// DefaultSize is about the size of a real puzzle input.
+	DefaultSize int
+
+	// Unit is what the size counts, such as "lines".
+	Unit string
+
+	generate func(r *rand.Rand, size int, w *bytes.Buffer)
+}
+
+var generators = map[int]Generator{}
+
+func register(g Generator) {
+	if _, ok := generators[g.Day]; ok {
+		panic(fmt.Sprintf("gen: generator for day %d registered twice", g.Day))
+	}
+	generators[g.Day] = g
+}
+
+// Lookup returns the generator for a day.
+func Lookup(day int) (Generator, error) {
+	g, ok := generators[day]
+	if !ok {
+		return Generator{}, fmt.Errorf("no generator for day %d", day)
+	}
+	return g, nil
+}
+
+// Days returns the days with a generator, in order.
+func Days() []int {
+	days := make([]int, 0, len(generators))
+	for day := range generators {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Generate returns the input for the seed, of the given size (see Unit).
+func (g Generator) Generate(seed int64, size int) ([]byte, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("invalid size %d (expected at least 1)", size)
+	}
+
+	// The values of a seeded math/rand source don't change between Go
+	// releases, so an input can be regenerated from its seed and size.
+	r := rand.New(rand.NewSource(seed))
+
+	var buf bytes.Buffer
+	g.generate(r, size, &buf)
+	return buf.Bytes(), nil
+}
+
+// pick returns a random element of the slice.
+func pick[T any](r *rand.Rand, s []T) T {
+	return s[r.Intn(len(s))]
+}
+
+// chance returns true with probability p.
+func chance(r *rand.Rand, p float64) bool {
+	return r.Float64() < p
+}
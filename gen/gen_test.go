@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	_ "github.com/Andoryuuta/AdventOfCode2024/days"
+)
+
+func TestGeneratedInputsParse(t *testing.T) {
+	for _, day := range Days() {
+		g, err := Lookup(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, size := range []int{1, 10, g.DefaultSize} {
+			for seed := int64(1); seed <= 5; seed++ {
+				t.Run(fmt.Sprintf("day%d/size_%d/seed_%d", day, size, seed), func(t *testing.T) {
+					input, err := g.Generate(seed, size)
+					if err != nil {
+						t.Fatalf("got error %v, expected nil", err)
+					}
+					parsed, err := d.Parse(bytes.NewReader(input))
+					if err != nil {
+						t.Fatalf("got error %v, expected nil, parsing:\n%s", err, input)
+					}
+					if size > 10 {
+						// Solving a full sized input is left to the benchmarks.
+						return
+					}
+					for _, part := range d.Parts() {
+						if _, err := d.Part(context.Background(), part, parsed); err != nil {
+							t.Errorf("got error %v solving part %d, expected nil, for:\n%s", err, part, input)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	for _, day := range Days() {
+		g, err := Lookup(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run(fmt.Sprintf("day%d", day), func(t *testing.T) {
+			first, _ := g.Generate(42, 20)
+			second, _ := g.Generate(42, 20)
+			if !bytes.Equal(first, second) {
+				t.Errorf("got different inputs for the same seed:\n%s\n%s", first, second)
+			}
+			if other, _ := g.Generate(43, 20); bytes.Equal(first, other) {
+				t.Errorf("got the same input for different seeds:\n%s", first)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	if _, err := Lookup(25); err == nil {
+		t.Errorf("got nil error, expected an error for a day without a generator")
+	}
+
+	g, err := Lookup(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Generate(1, 0); err == nil {
+		t.Errorf("got nil error, expected an error for size 0")
+	}
+}
@@ -0,0 +1,84 @@
+package grid
+
+import "fmt"
+
+// Direction is one of the eight compass directions, in clockwise order.
+type Direction int
+
+const (
+	Up Direction = iota
+	UpRight
+	Right
+	DownRight
+	Down
+	DownLeft
+	Left
+	UpLeft
+
+	numDirections = 8
+)
+
+// Cardinals are the four orthogonal directions, clockwise from Up.
+var Cardinals = [4]Direction{Up, Right, Down, Left}
+
+// AllDirections are all eight directions, clockwise from Up.
+var AllDirections = [8]Direction{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+
+var directionDeltas = [numDirections]Point{
+	Up:        {-1, 0},
+	UpRight:   {-1, 1},
+	Right:     {0, 1},
+	DownRight: {1, 1},
+	Down:      {1, 0},
+	DownLeft:  {1, -1},
+	Left:      {0, -1},
+	UpLeft:    {-1, -1},
+}
+
+var directionNames = [numDirections]string{"up", "up-right", "right", "down-right", "down", "down-left", "left", "up-left"}
+
+// Delta returns the offset of a single step in the direction.
+func (d Direction) Delta() Point {
+	return directionDeltas[d.normalize()]
+}
+
+// TurnRight returns the direction rotated 90 degrees clockwise.
+func (d Direction) TurnRight() Direction {
+	return (d + 2).normalize()
+}
+
+// TurnLeft returns the direction rotated 90 degrees counter-clockwise.
+func (d Direction) TurnLeft() Direction {
+	return (d - 2).normalize()
+}
+
+// Opposite returns the direction rotated 180 degrees.
+func (d Direction) Opposite() Direction {
+	return (d + 4).normalize()
+}
+
+func (d Direction) normalize() Direction {
+	return ((d % numDirections) + numDirections) % numDirections
+}
+
+func (d Direction) String() string {
+	if d < 0 || d >= numDirections {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return directionNames[d]
+}
+
+// ParseArrow returns the direction of an arrow marker ('^', '>', 'v' or '<').
+func ParseArrow(r rune) (Direction, bool) {
+	switch r {
+	case '^':
+		return Up, true
+	case '>':
+		return Right, true
+	case 'v':
+		return Down, true
+	case '<':
+		return Left, true
+	}
+	return 0, false
+}
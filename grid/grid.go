@@ -0,0 +1,157 @@
+// Package grid provides a generic 2D grid, with the points and directions
+// used to move around it.
+package grid
+
+import "fmt"
+
+// Point represents a single point in a grid.
+// This struct uses row/col (rather than x/y) for clarity.
+type Point struct {
+	Row int
+	Col int
+}
+
+// Add returns the point offset by another point.
+func (p Point) Add(offset Point) Point {
+	return Point{p.Row + offset.Row, p.Col + offset.Col}
+}
+
+// Move returns the neighbouring point in the given direction.
+func (p Point) Move(dir Direction) Point {
+	return p.Add(dir.Delta())
+}
+
+// Grid is a rectangular 2D grid of values.
+type Grid[T any] struct {
+	cells [][]T
+	cols  int
+}
+
+// New returns a grid of the given size, filled with zero values.
+func New[T any](rows int, cols int) *Grid[T] {
+	cells := make([][]T, rows)
+	for row := range cells {
+		cells[row] = make([]T, cols)
+	}
+	return &Grid[T]{cells, cols}
+}
+
+// FromRows returns a grid backed by the given rows.
+// All rows are required to be the same length.
+func FromRows[T any](rows [][]T) (*Grid[T], error) {
+	g := &Grid[T]{cells: rows}
+	for idx, row := range rows {
+		if idx == 0 {
+			g.cols = len(row)
+		} else if len(row) != g.cols {
+			return nil, fmt.Errorf("expected all rows to be the same length (row %d has length %d, expected %d)", idx+1, len(row), g.cols)
+		}
+	}
+	return g, nil
+}
+
+// Rows returns the number of rows in the grid.
+func (g *Grid[T]) Rows() int {
+	return len(g.cells)
+}
+
+// Cols returns the number of columns in the grid.
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds reports whether the point is within the grid.
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < len(g.cells) && p.Col >= 0 && p.Col < g.cols
+}
+
+// At returns the value at the point, and false if the point is out of bounds.
+func (g *Grid[T]) At(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row][p.Col], true
+}
+
+// Set sets the value at the point, and returns false if the point is out of bounds.
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Row][p.Col] = v
+	return true
+}
+
+// Row returns the values of a single row. The returned slice shares storage with the grid.
+func (g *Grid[T]) Row(row int) []T {
+	return g.cells[row]
+}
+
+// Each calls fn for every point in the grid, row by row.
+func (g *Grid[T]) Each(fn func(p Point, v T)) {
+	for row := range g.cells {
+		for col, v := range g.cells[row] {
+			fn(Point{row, col}, v)
+		}
+	}
+}
+
+// Neighbours returns the in-bounds neighbours of the point in the given directions.
+func (g *Grid[T]) Neighbours(p Point, dirs []Direction) []Point {
+	var neighbours []Point
+	for _, dir := range dirs {
+		if n := p.Move(dir); g.InBounds(n) {
+			neighbours = append(neighbours, n)
+		}
+	}
+	return neighbours
+}
+
+// Neighbours4 returns the in-bounds orthogonal neighbours of the point.
+func (g *Grid[T]) Neighbours4(p Point) []Point {
+	return g.Neighbours(p, Cardinals[:])
+}
+
+// Neighbours8 returns the in-bounds orthogonal and diagonal neighbours of the point.
+func (g *Grid[T]) Neighbours8(p Point) []Point {
+	return g.Neighbours(p, AllDirections[:])
+}
+
+// Clone returns a deep copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	clone := &Grid[T]{cells: make([][]T, len(g.cells)), cols: g.cols}
+	for row := range g.cells {
+		clone.cells[row] = append([]T(nil), g.cells[row]...)
+	}
+	return clone
+}
+
+// Find returns the first point (row by row) holding one of the given values.
+func Find[T comparable](g *Grid[T], values ...T) (Point, T, bool) {
+	for row := range g.cells {
+		for col, v := range g.cells[row] {
+			for _, target := range values {
+				if v == target {
+					return Point{row, col}, v, true
+				}
+			}
+		}
+	}
+	var zero T
+	return Point{}, zero, false
+}
+
+// FindAll returns every point holding one of the given values, row by row.
+func FindAll[T comparable](g *Grid[T], values ...T) []Point {
+	var points []Point
+	g.Each(func(p Point, v T) {
+		for _, target := range values {
+			if v == target {
+				points = append(points, p)
+				return
+			}
+		}
+	})
+	return points
+}
@@ -0,0 +1,119 @@
+package grid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFromRows(t *testing.T) {
+	var tests = []struct {
+		name          string
+		rows          []string
+		expectedRows  int
+		expectedCols  int
+		expectedError bool
+	}{
+		{"no rows", nil, 0, 0, false},
+		{"square", []string{"AB", "CD"}, 2, 2, false},
+		{"rectangle", []string{"ABC", "DEF"}, 2, 3, false},
+		{"rows different length", []string{"AB", "CDE"}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := FromRows(runeRows(tt.rows...))
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("got nil error, expected !nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if g.Rows() != tt.expectedRows || g.Cols() != tt.expectedCols {
+				t.Errorf("got %dx%d grid, expected %dx%d", g.Rows(), g.Cols(), tt.expectedRows, tt.expectedCols)
+			}
+		})
+	}
+}
+
+// runeRows converts rows of text to rows of runes.
+func runeRows(rows ...string) [][]rune {
+	var runes [][]rune
+	for _, row := range rows {
+		runes = append(runes, []rune(row))
+	}
+	return runes
+}
+
+func TestAccess(t *testing.T) {
+	g, err := FromRows(runeRows("#..", ".^.", "..#"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := g.At(Point{Row: 2, Col: 2}); !ok || v != '#' {
+		t.Errorf("got %q (ok: %v), expected '#'", v, ok)
+	}
+	for _, p := range []Point{{Row: -1, Col: 0}, {Row: 0, Col: -1}, {Row: 3, Col: 0}, {Row: 0, Col: 3}} {
+		if _, ok := g.At(p); ok {
+			t.Errorf("got in-bounds access for %+v, expected out of bounds", p)
+		}
+		if g.Set(p, 'X') {
+			t.Errorf("got successful set for %+v, expected out of bounds", p)
+		}
+	}
+
+	p, v, ok := Find(g, '^', 'v')
+	if !ok || v != '^' || p != (Point{Row: 1, Col: 1}) {
+		t.Errorf("got %+v %q (ok: %v), expected guard at 1,1", p, v, ok)
+	}
+	if got := FindAll(g, '#'); !slices.Equal(got, []Point{{Row: 0, Col: 0}, {Row: 2, Col: 2}}) {
+		t.Errorf("got obstructions %+v", got)
+	}
+
+	clone := g.Clone()
+	clone.Set(Point{Row: 0, Col: 0}, '.')
+	if v, _ := g.At(Point{Row: 0, Col: 0}); v != '#' {
+		t.Errorf("modifying the clone modified the original grid")
+	}
+
+	if got := len(g.Neighbours4(Point{Row: 0, Col: 0})); got != 2 {
+		t.Errorf("got %d corner neighbours (4 directions), expected 2", got)
+	}
+	if got := len(g.Neighbours8(Point{Row: 1, Col: 1})); got != 8 {
+		t.Errorf("got %d centre neighbours (8 directions), expected 8", got)
+	}
+}
+
+func TestDirection(t *testing.T) {
+	var tests = []struct {
+		dir                   Direction
+		right, left, opposite Direction
+		expectedDelta         Point
+	}{
+		{Up, Right, Left, Down, Point{Row: -1, Col: 0}},
+		{Right, Down, Up, Left, Point{Row: 0, Col: 1}},
+		{Down, Left, Right, Up, Point{Row: 1, Col: 0}},
+		{Left, Up, Down, Right, Point{Row: 0, Col: -1}},
+		{UpRight, DownRight, UpLeft, DownLeft, Point{Row: -1, Col: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir.String(), func(t *testing.T) {
+			if got := tt.dir.TurnRight(); got != tt.right {
+				t.Errorf("got right turn %v, expected %v", got, tt.right)
+			}
+			if got := tt.dir.TurnLeft(); got != tt.left {
+				t.Errorf("got left turn %v, expected %v", got, tt.left)
+			}
+			if got := tt.dir.Opposite(); got != tt.opposite {
+				t.Errorf("got opposite %v, expected %v", got, tt.opposite)
+			}
+			if got := tt.dir.Delta(); got != tt.expectedDelta {
+				t.Errorf("got delta %+v, expected %+v", got, tt.expectedDelta)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+)
+
+// GoldenExt is appended to an input's name to get its golden file, which holds
+// the output of solving the input (see GoldenOutput). Golden files record more
+// than the manifest, such as answers' metadata, but the answers they share
+// must agree (see aoctest.Golden).
+const GoldenExt = ".expected"
+
+// GoldenOutput solves every part of the day from the input, and returns what
+// is compared with a golden file: a line per part with its answer and
+// metadata, or the error that stopped it.
+//
+//	part 1: 41 (infinite_loop: false)
+//	part 2: 6
+func GoldenOutput(d *aoc.Day, input []byte) string {
+	// The input is parsed without its file name, so errors don't depend on
+	// where the tests are run from.
+	parsed, err := d.Parse(bytes.NewReader(input))
+	if err != nil {
+		return fmt.Sprintf("parse error: %v\n", err)
+	}
+
+	var sb strings.Builder
+	for _, part := range d.Parts() {
+		answer, err := d.Part(context.Background(), part, parsed)
+		if err != nil {
+			fmt.Fprintf(&sb, "part %d: error: %v\n", part, err)
+			continue
+		}
+		fmt.Fprintf(&sb, "part %d: %v", part, answer)
+		if meta := answer.MetaString(); meta != "" {
+			fmt.Fprintf(&sb, " %s", meta)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// GoldenAnswers returns the answer of each part which was solved in a golden
+// file's output.
+func GoldenAnswers(output string) map[int]string {
+	answers := map[int]string{}
+	for _, line := range strings.Split(output, "\n") {
+		label, rest, ok := strings.Cut(line, ": ")
+		if !ok || !strings.HasPrefix(label, "part ") || strings.HasPrefix(rest, "error: ") {
+			continue
+		}
+		part, err := strconv.Atoi(strings.TrimPrefix(label, "part "))
+		if err != nil {
+			continue
+		}
+		answer, _, _ := strings.Cut(rest, " ")
+		answers[part] = answer
+	}
+	return answers
+}
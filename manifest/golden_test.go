@@ -0,0 +1,85 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+)
+
+// countSolver counts the lines of the input, and fails part 2 for empty input.
+type countSolver struct{}
+
+func (countSolver) Parse(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(data), "x") {
+		return nil, errors.New("unexpected x")
+	}
+	return strings.Fields(string(data)), nil
+}
+
+func (countSolver) Part1(ctx context.Context, input []string) (aoc.Answer, error) {
+	return aoc.IntAnswer(len(input)).WithMeta("empty", len(input) == 0), nil
+}
+
+func (countSolver) Part2(ctx context.Context, input []string) (aoc.Answer, error) {
+	if len(input) == 0 {
+		return aoc.Answer{}, errors.New("no lines")
+	}
+	v, _ := strconv.Atoi(input[0])
+	return aoc.IntAnswer(v), nil
+}
+
+func init() {
+	aoc.Register[[]string](25, countSolver{})
+}
+
+func TestGoldenOutput(t *testing.T) {
+	d, err := aoc.Lookup(25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"answers with metadata", "7\n8\n", "part 1: 2 (empty: false)\npart 2: 7\n"},
+		{"part error", "", "part 1: 0 (empty: true)\npart 2: error: no lines\n"},
+		{"parse error", "x", "parse error: unexpected x\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GoldenOutput(d, []byte(tt.input)); got != tt.expected {
+				t.Errorf("got %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGoldenAnswers(t *testing.T) {
+	var tests = []struct {
+		output   string
+		expected map[int]string
+	}{
+		{"part 1: 41 (infinite_loop: false)\npart 2: 6\n", map[int]string{1: "41", 2: "6"}},
+		{"part 1: 0 (empty: true)\npart 2: error: no lines\n", map[int]string{1: "0"}},
+		{"parse error: unexpected x\n", map[int]string{}},
+	}
+
+	for _, tt := range tests {
+		if got := GoldenAnswers(tt.output); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("got %v for %q, expected %v", got, tt.output, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package manifest records the known-correct answers of each puzzle input, so
+// solutions can be checked for regressions.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Andoryuuta/AdventOfCode2024/vault"
+)
+
+// DefaultFile is the name of the manifest within the data directory.
+const DefaultFile = "answers.json"
+
+// Manifest maps input files, relative to the data directory (e.g.
+// "day1/input_example"), to the known-correct answer of each part.
+type Manifest map[string]map[int]string
+
+// Load reads a manifest file. A missing file is treated as an empty manifest.
+func Load(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Manifest{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	m := Manifest{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %v", path, err)
+	}
+	return m, nil
+}
+
+// Save writes the manifest file, with inputs and parts in sorted order.
+func (m Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Expected returns the known-correct answer of a part for the given input.
+func (m Manifest) Expected(input string, part int) (string, bool) {
+	answer, ok := m[input][part]
+	return answer, ok
+}
+
+// Set records the known-correct answer of a part for the given input.
+func (m Manifest) Set(input string, part int, answer string) {
+	if _, ok := m[input]; !ok {
+		m[input] = map[int]string{}
+	}
+	m[input][part] = answer
+}
+
+// Key returns the manifest key of an input file within the data directory.
+func Key(dataDir string, inputPath string) (string, error) {
+	rel, err := filepath.Rel(dataDir, inputPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// Status is the result of checking an answer against the manifest.
+type Status string
+
+const (
+	StatusPass    Status = "pass"
+	StatusFail    Status = "fail"
+	StatusMissing Status = "missing"
+	StatusError   Status = "error"
+)
+
+// Check compares an answer to the known-correct answer of a part.
+func (m Manifest) Check(input string, part int, answer string) Status {
+	expected, ok := m.Expected(input, part)
+	if !ok {
+		return StatusMissing
+	}
+	if expected != answer {
+		return StatusFail
+	}
+	return StatusPass
+}
+
+// InputFiles returns the input files of a day within the data directory: every
+// file named "input" or starting with "input_" (e.g. "input_example_part1").
+// Files with an extension, such as download metadata, are not inputs, except
+// that a sealed input (e.g. "input.vault") is returned as the input it holds,
+// to be read with vault.ReadFile.
+func InputFiles(dataDir string, day int) ([]string, error) {
+	dir := filepath.Join(dataDir, fmt.Sprintf("day%d", day))
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var inputs []string
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), vault.Ext)
+		if entry.IsDir() || strings.Contains(name, ".") || seen[name] {
+			continue
+		}
+		if name == "input" || strings.HasPrefix(name, "input_") {
+			inputs = append(inputs, filepath.Join(dir, name))
+			seen[name] = true
+		}
+	}
+	return inputs, nil
+}
@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFile)
+
+	m, err := Load(path)
+	if err != nil || len(m) != 0 {
+		t.Fatalf("got %v (err: %v), expected empty manifest for missing file", m, err)
+	}
+
+	m.Set("day1/input_example", 1, "11")
+	m.Set("day1/input_example", 2, "31")
+	if err := m.Save(path); err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if !reflect.DeepEqual(loaded, m) {
+		t.Errorf("got %v, expected %v", loaded, m)
+	}
+
+	var tests = []struct {
+		input    string
+		part     int
+		answer   string
+		expected Status
+	}{
+		{"day1/input_example", 1, "11", StatusPass},
+		{"day1/input_example", 2, "30", StatusFail},
+		{"day1/input", 1, "11", StatusMissing},
+	}
+	for _, tt := range tests {
+		if got := loaded.Check(tt.input, tt.part, tt.answer); got != tt.expected {
+			t.Errorf("%s part %d: got %v, expected %v", tt.input, tt.part, got, tt.expected)
+		}
+	}
+}
+
+func TestInputFiles(t *testing.T) {
+	dataDir := t.TempDir()
+	dir := filepath.Join(dataDir, "day3")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"input", "input.meta.json", "input.vault", "input_example_part1", "input_example_part2", "input_sealed.vault", "notes"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	inputs, err := InputFiles(dataDir, 3)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+
+	var keys []string
+	for _, input := range inputs {
+		key, err := Key(dataDir, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	expected := []string{"day3/input", "day3/input_example_part1", "day3/input_example_part2", "day3/input_sealed"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("got %v, expected %v", keys, expected)
+	}
+
+	if inputs, err := InputFiles(dataDir, 4); err != nil || len(inputs) != 0 {
+		t.Errorf("got %v (err: %v), expected no inputs for missing day", inputs, err)
+	}
+}
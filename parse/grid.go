@@ -0,0 +1,32 @@
+package parse
+
+import (
+	"io"
+
+	"github.com/Andoryuuta/AdventOfCode2024/grid"
+)
+
+// Grid parses a grid of runes, one row per line.
+// All rows are required to be the same length.
+func Grid(r io.Reader) (*grid.Grid[rune], error) {
+	var rows [][]rune
+
+	lines := NewLines(r)
+	for lines.Next() {
+		line := lines.Line()
+		row := []rune(line.Text)
+
+		// Verify all rows are the same length
+		if len(rows) > 0 && len(rows[0]) != len(row) {
+			column := min(len(rows[0]), len(row)) + 1
+			return nil, line.Errorf(column, "expected all rows to be the same length (got %d, expected %d)", len(row), len(rows[0]))
+		}
+
+		rows = append(rows, row)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+
+	return grid.FromRows(rows)
+}
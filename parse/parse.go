@@ -0,0 +1,271 @@
+// Package parse is a toolkit for the line-oriented puzzle input formats.
+//
+// Every error it produces is an *Error carrying the file, line and column of
+// the offending input, so parsers built on it report accurate positions.
+package parse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is a parse error at a position in the input.
+type Error struct {
+	File   string // may be empty, if the input isn't a named file
+	Line   int    // 1-based
+	Column int    // 1-based (in runes), or 0 if the error applies to the whole line
+	Text   string // text of the offending line
+	Msg    string
+	Err    error // underlying error, if any
+
+	// Details of what was being parsed, if known, to help explain the error.
+	Field     string // text of the offending field
+	Separator string // separator the line was expected to be split on
+}
+
+func (e *Error) Error() string {
+	var pos string
+	switch {
+	case e.File != "" && e.Column > 0:
+		pos = fmt.Sprintf("%s:%d:%d", e.File, e.Line, e.Column)
+	case e.File != "":
+		pos = fmt.Sprintf("%s:%d", e.File, e.Line)
+	case e.Column > 0:
+		pos = fmt.Sprintf("line %d, column %d", e.Line, e.Column)
+	default:
+		pos = fmt.Sprintf("line %d", e.Line)
+	}
+	return pos + ": " + e.Msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NameOf returns the name of the input if it is a named file (such as an
+// *os.File), or an empty string.
+func NameOf(r io.Reader) string {
+	if named, ok := r.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
+
+// Line is a single line of input.
+type Line struct {
+	File   string
+	Number int // 1-based
+	Text   string
+}
+
+// Errorf returns an error at the given column (or the whole line, for column 0).
+func (l Line) Errorf(column int, format string, args ...any) *Error {
+	return &Error{
+		File:   l.File,
+		Line:   l.Number,
+		Column: column,
+		Text:   l.Text,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// Field is a part of a line, such as a single number in a list.
+type Field struct {
+	Text   string
+	Column int // 1-based column (in runes) of the first character
+}
+
+// Split splits the line on the exact separator, and requires n fields
+// (or any number of fields, if n < 0).
+func (l Line) Split(sep string, n int) ([]Field, error) {
+	var fields []Field
+	offset := 0
+	for _, text := range strings.Split(l.Text, sep) {
+		fields = append(fields, Field{text, l.column(offset)})
+		offset += len(text) + len(sep)
+	}
+
+	if n >= 0 && len(fields) != n {
+		column := 0
+		if len(fields) > n {
+			// Point at the first unexpected separator.
+			column = fields[n].Column - utf8.RuneCountInString(sep)
+		}
+		e := l.Errorf(column, "expected %d fields separated by %q, got %d", n, sep, len(fields))
+		e.Separator = sep
+		return nil, e
+	}
+	return fields, nil
+}
+
+// Fields splits the line on runs of spaces and tabs, ignoring any at either
+// end, and requires n fields (or any number of fields, if n < 0).
+func (l Line) Fields(n int) ([]Field, error) {
+	var fields []Field
+	start := -1
+	for i := 0; i <= len(l.Text); i++ {
+		blank := i == len(l.Text) || l.Text[i] == ' ' || l.Text[i] == '\t'
+		switch {
+		case blank && start >= 0:
+			fields = append(fields, Field{l.Text[start:i], l.column(start)})
+			start = -1
+		case !blank && start < 0:
+			start = i
+		}
+	}
+
+	if n >= 0 && len(fields) != n {
+		column := 0
+		if len(fields) > n {
+			// Point at the first unexpected field.
+			column = fields[n].Column
+		}
+		return nil, l.Errorf(column, "expected %d fields separated by whitespace, got %d", n, len(fields))
+	}
+	return fields, nil
+}
+
+// column returns the 1-based rune column of a byte offset into the line.
+func (l Line) column(offset int) int {
+	return utf8.RuneCountInString(l.Text[:offset]) + 1
+}
+
+// Uint parses a field of the line as an unsigned decimal integer.
+func (l Line) Uint(f Field) (uint64, error) {
+	v, err := strconv.ParseUint(f.Text, 10, 64)
+	if err != nil {
+		e := l.Errorf(f.Column, "expected unsigned decimal integer, got %q", f.Text)
+		e.Err = err
+		e.Field = f.Text
+		return 0, e
+	}
+	return v, nil
+}
+
+// Int parses a field of the line as a (signed) decimal integer.
+func (l Line) Int(f Field) (int, error) {
+	v, err := strconv.Atoi(f.Text)
+	if err != nil {
+		e := l.Errorf(f.Column, "expected decimal integer, got %q", f.Text)
+		e.Err = err
+		e.Field = f.Text
+		return 0, e
+	}
+	return v, nil
+}
+
+// Uints parses the line as a list of unsigned decimal integers separated by sep.
+func (l Line) Uints(sep string) ([]uint64, error) {
+	fields, _ := l.Split(sep, -1)
+	values := make([]uint64, 0, len(fields))
+	for _, f := range fields {
+		v, err := l.Uint(f)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Ints parses the line as a list of decimal integers separated by sep.
+func (l Line) Ints(sep string) ([]int, error) {
+	fields, _ := l.Split(sep, -1)
+	values := make([]int, 0, len(fields))
+	for _, f := range fields {
+		v, err := l.Int(f)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Lines iterates over the lines of an input, numbering them from 1.
+//
+//	lines := parse.NewLines(reader)
+//	for lines.Next() {
+//		line := lines.Line()
+//		...
+//	}
+//	if err := lines.Err(); err != nil {
+//		...
+//	}
+type Lines struct {
+	file    string
+	scanner *bufio.Scanner
+	line    Line
+}
+
+// NewLines returns an iterator over the lines of the input.
+func NewLines(r io.Reader) *Lines {
+	return &Lines{
+		file:    NameOf(r),
+		scanner: bufio.NewScanner(r),
+	}
+}
+
+// Next advances to the next line, returning false at the end of the input.
+func (l *Lines) Next() bool {
+	if !l.scanner.Scan() {
+		return false
+	}
+	l.line = Line{l.file, l.line.Number + 1, l.scanner.Text()}
+	return true
+}
+
+// Line returns the current line.
+func (l *Lines) Line() Line {
+	return l.line
+}
+
+// Err returns the first non-EOF error encountered while reading the input.
+func (l *Lines) Err() error {
+	if err := l.scanner.Err(); err != nil {
+		return &Error{File: l.file, Line: l.line.Number + 1, Msg: err.Error(), Err: err}
+	}
+	return nil
+}
+
+// All returns every line of the input.
+func All(r io.Reader) ([]Line, error) {
+	var lines []Line
+	it := NewLines(r)
+	for it.Next() {
+		lines = append(lines, it.Line())
+	}
+	return lines, it.Err()
+}
+
+// Sections splits the input into sections of lines separated by blank lines.
+// Each blank line ends a section, so consecutive blank lines produce empty
+// sections, except that blank lines at the end of the input are ignored.
+func Sections(r io.Reader) ([][]Line, error) {
+	lines, err := All(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trailing blank lines don't start new sections.
+	for len(lines) > 0 && lines[len(lines)-1].Text == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	sections := [][]Line{{}}
+	for _, line := range lines {
+		if line.Text == "" {
+			sections = append(sections, []Line{})
+			continue
+		}
+		sections[len(sections)-1] = append(sections[len(sections)-1], line)
+	}
+	return sections, nil
+}
@@ -0,0 +1,247 @@
+package parse
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineErrors(t *testing.T) {
+	var tests = []struct {
+		name           string
+		input          string
+		parseLine      func(line Line) error
+		expectedLine   int
+		expectedColumn int
+	}{
+		{
+			"first line is line 1",
+			"x",
+			func(line Line) error {
+				_, err := line.Uints(" ")
+				return err
+			},
+			1,
+			1,
+		},
+		{
+			"column of bad integer in list",
+			"1 2 3\n4 5 -6 7",
+			func(line Line) error {
+				_, err := line.Uints(" ")
+				return err
+			},
+			2,
+			5,
+		},
+		{
+			"column of unexpected separator",
+			"1   2   3",
+			func(line Line) error {
+				_, err := line.Split("   ", 2)
+				return err
+			},
+			1,
+			6,
+		},
+		{
+			"missing separator applies to whole line",
+			"1   2\n3\t4",
+			func(line Line) error {
+				_, err := line.Split("   ", 2)
+				return err
+			},
+			2,
+			0,
+		},
+		{
+			"column of unexpected whitespace-separated field",
+			" 1 \t 2  3",
+			func(line Line) error {
+				_, err := line.Fields(2)
+				return err
+			},
+			1,
+			9,
+		},
+		{
+			"missing whitespace-separated field applies to whole line",
+			"1 2\n3",
+			func(line Line) error {
+				_, err := line.Fields(2)
+				return err
+			},
+			2,
+			0,
+		},
+		{
+			"columns count runes",
+			"é,ü,x",
+			func(line Line) error {
+				_, err := line.Ints(",")
+				return err
+			},
+			1,
+			1,
+		},
+		{
+			"columns count runes after multi-byte fields",
+			"1|é",
+			func(line Line) error {
+				fields, err := line.Split("|", 2)
+				if err != nil {
+					return err
+				}
+				_, err = line.Int(fields[1])
+				return err
+			},
+			1,
+			3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			lines := NewLines(strings.NewReader(tt.input))
+			for err == nil && lines.Next() {
+				err = tt.parseLine(lines.Line())
+			}
+
+			var parseErr *Error
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("got error %v, expected *Error", err)
+			}
+			if parseErr.Line != tt.expectedLine || parseErr.Column != tt.expectedColumn {
+				t.Errorf("got position %d:%d, expected %d:%d (%v)", parseErr.Line, parseErr.Column, tt.expectedLine, tt.expectedColumn, err)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected []Field
+	}{
+		{"", nil},
+		{" \t ", nil},
+		{"1 2", []Field{{"1", 1}, {"2", 3}}},
+		{"\té  x\t\t12 ", []Field{{"é", 2}, {"x", 5}, {"12", 8}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			fields, err := Line{Number: 1, Text: tt.input}.Fields(-1)
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if !reflect.DeepEqual(fields, tt.expected) {
+				t.Errorf("got %v, expected %v", fields, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorIncludesFileName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(path, []byte("1   2\n3   x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lines := NewLines(file)
+	for lines.Next() {
+		line := lines.Line()
+		fields, _ := line.Split("   ", 2)
+		if _, err = line.Uint(fields[1]); err != nil {
+			break
+		}
+	}
+
+	if expected := path + ":2:5: "; err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("got error %v, expected prefix %q", err, expected)
+	}
+}
+
+func TestSections(t *testing.T) {
+	texts := func(sections [][]Line) [][]string {
+		result := [][]string{}
+		for _, section := range sections {
+			texts := []string{}
+			for _, line := range section {
+				texts = append(texts, line.Text)
+			}
+			result = append(result, texts)
+		}
+		return result
+	}
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected [][]string
+	}{
+		{"empty input", "", [][]string{}},
+		{"single section", "a\nb\n", [][]string{{"a", "b"}}},
+		{"two sections", "a\nb\n\nc\n", [][]string{{"a", "b"}, {"c"}}},
+		{"trailing blank lines ignored", "a\n\nc\n\n\n", [][]string{{"a"}, {"c"}}},
+		{"leading blank line", "\nc", [][]string{{}, {"c"}}},
+		{"consecutive blank lines", "a\n\n\nc", [][]string{{"a"}, {}, {"c"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, err := Sections(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if got := texts(sections); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+
+	sections, _ := Sections(strings.NewReader("a\n\nc"))
+	if line := sections[1][0]; line.Number != 3 {
+		t.Errorf("got line number %d, expected 3", line.Number)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	var tests = []struct {
+		name         string
+		input        string
+		expectedRows int
+		expectedCols int
+	}{
+		{"empty input", "", 0, 0},
+		{"square", "AB\nCD\n", 2, 2},
+		{"rectangle, no trailing newline", "ABC\nDEF", 2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Grid(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if g.Rows() != tt.expectedRows || g.Cols() != tt.expectedCols {
+				t.Errorf("got %dx%d grid, expected %dx%d", g.Rows(), g.Cols(), tt.expectedRows, tt.expectedCols)
+			}
+		})
+	}
+
+	_, err := Grid(strings.NewReader("ABC\nABC\nAB\n"))
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 || parseErr.Column != 3 {
+		t.Errorf("got error %v, expected error at 3:3", err)
+	}
+}
@@ -0,0 +1,75 @@
+// Package puzzle extracts the examples from a puzzle description page, as
+// saved from (or downloaded by the client from) the Advent of Code website.
+//
+// Each part of the puzzle is an <article class="day-desc">. Its examples are
+// the <pre><code> blocks, and the answer for the example is the last number
+// emphasised as <code><em>N</em></code>, which is how the descriptions
+// highlight it ("... a total distance of 11").
+package puzzle
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Description is the parsed description of a puzzle.
+type Description struct {
+	Day   int
+	Title string
+	Parts []Part // part 2 is only included in the page once part 1 is solved
+}
+
+// Part is the description of a single part of a puzzle.
+type Part struct {
+	Blocks []string // text of every <pre><code> block, in order
+	Answer string   // expected answer for the example, or "" if none was found
+}
+
+var (
+	articleRegexp = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	titleRegexp   = regexp.MustCompile(`<h2>--- Day (\d+): (.*?) ---</h2>`)
+	blockRegexp   = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	tagRegexp     = regexp.MustCompile(`<[^>]*>`)
+
+	// The answer is emphasised either inside or around the code element.
+	answerRegexp = regexp.MustCompile(`<code><em>([^<]*)</em></code>|<em><code>([^<]*)</code></em>`)
+)
+
+// Parse parses a puzzle description page.
+func Parse(page string) (*Description, error) {
+	articles := articleRegexp.FindAllStringSubmatch(page, -1)
+	if len(articles) == 0 {
+		return nil, errors.New("no puzzle description found in the page")
+	}
+
+	description := &Description{}
+	if title := titleRegexp.FindStringSubmatch(articles[0][1]); title != nil {
+		description.Day, _ = strconv.Atoi(title[1])
+		description.Title = html.UnescapeString(title[2])
+	}
+
+	for _, article := range articles {
+		var part Part
+		for _, block := range blockRegexp.FindAllStringSubmatch(article[1], -1) {
+			part.Blocks = append(part.Blocks, text(block[1]))
+		}
+
+		for _, answer := range answerRegexp.FindAllStringSubmatch(article[1], -1) {
+			value := text(answer[1] + answer[2])
+			if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+				part.Answer = value
+			}
+		}
+		description.Parts = append(description.Parts, part)
+	}
+	return description, nil
+}
+
+// text returns the plain text of an HTML fragment, without the trailing
+// newline of a block (the example files don't end with one).
+func text(fragment string) string {
+	return strings.TrimRight(html.UnescapeString(tagRegexp.ReplaceAllString(fragment, "")), "\n")
+}
@@ -0,0 +1,62 @@
+package puzzle
+
+import (
+	"reflect"
+	"testing"
+)
+
+// page mimics the structure of a puzzle description with both parts solved.
+const page = `<!DOCTYPE html>
+<html lang="en-us">
+<body>
+<main>
+<article class="day-desc"><h2>--- Day 3: Mull It Over ---</h2><p>Some text.</p>
+<p>For example, consider the following section of memory:</p>
+<pre><code>x<em>mul(2,4)</em>%&amp;mul[3,7]!@^do_not_<em>mul(5,5)</em>
+</code></pre>
+<p>Intermediate results like <code>2*4</code> and <code><em>8</em></code> are also emphasised,
+but adding up the results produces <code><em>161</em></code> (<code>2*4 + 5*5</code>).</p>
+</article>
+<p>Your puzzle answer was <code>12345</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>This time, the example is different:</p>
+<pre><code>xmul(2,4)&amp;don't()_mul(5,5)
+</code></pre>
+<p>A diagram, which isn't the example input:</p>
+<pre><code>A
+B
+</code></pre>
+<p>This time, the sum of the results is <em><code>48</code></em>, and <em>not</em> <code><em>forty-eight</em></code>.</p>
+</article>
+</main>
+</body>
+</html>`
+
+func TestParse(t *testing.T) {
+	description, err := Parse(page)
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+
+	expected := &Description{
+		Day:   3,
+		Title: "Mull It Over",
+		Parts: []Part{
+			{
+				Blocks: []string{"xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)"},
+				Answer: "161",
+			},
+			{
+				Blocks: []string{"xmul(2,4)&don't()_mul(5,5)", "A\nB"},
+				Answer: "48",
+			},
+		},
+	}
+	if !reflect.DeepEqual(description, expected) {
+		t.Errorf("got %+v, expected %+v", description, expected)
+	}
+
+	if _, err := Parse("<html>not a puzzle</html>"); err == nil {
+		t.Errorf("got nil error for a page without a description, expected !nil")
+	}
+}
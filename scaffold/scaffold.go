@@ -0,0 +1,165 @@
+// Package scaffold generates the boilerplate for a new day: the solution
+// package registered with the aoc runner, a table-driven test skeleton with
+// fuzz and benchmark stubs, the import wiring in days/days.go, and the data directory.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Options describes the day to generate.
+type Options struct {
+	Root    string // repository root, holding go.mod
+	DataDir string // data directory, relative to Root
+	Day     int
+	Title   string // puzzle title for the package doc, may be empty
+}
+
+// Generate creates the files for a new day and returns the paths it created.
+// It refuses to overwrite an existing day's package, and leaves any existing
+// files in the day's data directory (such as a fetched input) untouched.
+func Generate(opts Options) ([]string, error) {
+	if opts.Day < 1 || opts.Day > 25 {
+		return nil, fmt.Errorf("invalid day %d", opts.Day)
+	}
+
+	module, err := modulePath(filepath.Join(opts.Root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	data := templateData{Module: module, DataDir: filepath.ToSlash(opts.DataDir), Day: opts.Day, Title: opts.Title}
+
+	// Check everything before writing anything, so a refusal leaves no partial day behind.
+	pkgDir := filepath.Join(opts.Root, "days", fmt.Sprintf("day%d", opts.Day))
+	if _, err := os.Stat(pkgDir); err == nil {
+		return nil, fmt.Errorf("%s already exists", pkgDir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	daysFile := filepath.Join(opts.Root, "days", "days.go")
+	wiring, err := os.ReadFile(daysFile)
+	if err != nil {
+		return nil, err
+	}
+	wiring, err = addImport(wiring, module, opts.Day)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", daysFile, err)
+	}
+
+	files := []struct {
+		path string
+		tmpl *template.Template
+	}{
+		{filepath.Join(pkgDir, fmt.Sprintf("day%d.go", opts.Day)), solverTemplate},
+		{filepath.Join(pkgDir, fmt.Sprintf("day%d_test.go", opts.Day)), testTemplate},
+	}
+	sources := make([][]byte, len(files))
+	for i, file := range files {
+		if sources[i], err = render(file.tmpl, data); err != nil {
+			return nil, fmt.Errorf("%s: %v", file.path, err)
+		}
+	}
+
+	var created []string
+	if err := os.Mkdir(pkgDir, 0o755); err != nil {
+		return nil, err
+	}
+	for i, file := range files {
+		if err := os.WriteFile(file.path, sources[i], 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, file.path)
+	}
+	if err := os.WriteFile(daysFile, wiring, 0o644); err != nil {
+		return created, err
+	}
+
+	// Git doesn't track empty directories, so a new data directory gets a
+	// placeholder (which isn't treated as an input file, as it has a dot).
+	dataDir := filepath.Join(opts.Root, opts.DataDir, fmt.Sprintf("day%d", opts.Day))
+	if _, err := os.Stat(dataDir); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(dataDir, 0o755); err != nil {
+			return created, err
+		}
+		keep := filepath.Join(dataDir, ".gitkeep")
+		if err := os.WriteFile(keep, nil, 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, keep)
+	}
+
+	return created, nil
+}
+
+// render executes a template and gofmts the result.
+func render(tmpl *template.Template, data templateData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// modulePath reads the module path from a go.mod file.
+func modulePath(goMod string) (string, error) {
+	file, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s: no module directive", goMod)
+}
+
+var dayImportRegex = regexp.MustCompile(`^\s*_ "(.+)/days/day(\d+)"\s*$`)
+
+// addImport adds the blank import of a day's package to the source of
+// days.go, keeping the imports in day order.
+func addImport(src []byte, module string, day int) ([]byte, error) {
+	lines := strings.Split(string(src), "\n")
+
+	insertAt := -1
+	for i, line := range lines {
+		match := dayImportRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		existing, _ := strconv.Atoi(match[2])
+		if existing == day {
+			return nil, fmt.Errorf("day %d is already imported", day)
+		}
+		if existing < day || insertAt == -1 {
+			insertAt = i + 1
+			if existing > day {
+				insertAt = i
+			}
+		}
+	}
+	if insertAt == -1 {
+		return nil, errors.New("cannot find the blank imports of the days")
+	}
+
+	importLine := fmt.Sprintf("\t_ %q", fmt.Sprintf("%s/days/day%d", module, day))
+	lines = append(lines[:insertAt], append([]string{importLine}, lines[insertAt:]...)...)
+	return format.Source([]byte(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,126 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDaysFile = `// Package days imports every day.
+package days
+
+import (
+	_ "example.com/aoc/days/day1"
+	_ "example.com/aoc/days/day3"
+)
+`
+
+// newTestRoot creates a repository root with the given days already wired up.
+func newTestRoot(t *testing.T) string {
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":       "module example.com/aoc\n\ngo 1.21\n",
+		"days/days.go": testDaysFile,
+	} {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestGenerate(t *testing.T) {
+	root := newTestRoot(t)
+	created, err := Generate(Options{Root: root, DataDir: "challenge_data", Day: 2, Title: "Red-Nosed Reports"})
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if len(created) != 3 {
+		t.Errorf("got created files %v, expected package, test and data directory placeholder", created)
+	}
+
+	source, err := os.ReadFile(filepath.Join(root, "days", "day2", "day2.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"// Package day2 solves day 2 of Advent of Code 2024: Red-Nosed Reports.",
+		`"example.com/aoc/aoc"`,
+		"aoc.Register[[]string](2, Solver{})",
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("got day2.go without %q", expected)
+		}
+	}
+
+	wiring, err := os.ReadFile(filepath.Join(root, "days", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	day1 := strings.Index(string(wiring), "/days/day1\"")
+	day2 := strings.Index(string(wiring), "/days/day2\"")
+	day3 := strings.Index(string(wiring), "/days/day3\"")
+	if !(day1 < day2 && day2 < day3) {
+		t.Errorf("got days.go:\n%s\nexpected day2 to be imported between day1 and day3", wiring)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "challenge_data", "day2", ".gitkeep")); err != nil {
+		t.Errorf("got error %v, expected the data directory to be created", err)
+	}
+}
+
+func TestGenerateRefusesToOverwrite(t *testing.T) {
+	var tests = []struct {
+		name string
+		day  int
+	}{
+		{"existing package", 4},
+		{"already imported", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newTestRoot(t)
+			if err := os.MkdirAll(filepath.Join(root, "days", "day4"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Generate(Options{Root: root, DataDir: "challenge_data", Day: tt.day}); err == nil {
+				t.Errorf("got nil error, expected !nil")
+			}
+			if wiring, _ := os.ReadFile(filepath.Join(root, "days", "days.go")); string(wiring) != testDaysFile {
+				t.Errorf("got days.go modified after refusal:\n%s", wiring)
+			}
+			if _, err := os.Stat(filepath.Join(root, "challenge_data")); err == nil {
+				t.Errorf("got data directory created after refusal, expected none")
+			}
+		})
+	}
+}
+
+func TestGenerateKeepsExistingData(t *testing.T) {
+	root := newTestRoot(t)
+	input := filepath.Join(root, "challenge_data", "day7", "input")
+	if err := os.MkdirAll(filepath.Dir(input), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(input, []byte("fetched"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := Generate(Options{Root: root, DataDir: "challenge_data", Day: 7})
+	if err != nil {
+		t.Fatalf("got error %v, expected nil", err)
+	}
+	if len(created) != 2 {
+		t.Errorf("got created files %v, expected only the package and test", created)
+	}
+	if data, _ := os.ReadFile(input); string(data) != "fetched" {
+		t.Errorf("got input %q, expected it to be left untouched", data)
+	}
+}
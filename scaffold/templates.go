@@ -0,0 +1,178 @@
+package scaffold
+
+import "text/template"
+
+// templateData is passed to every template.
+type templateData struct {
+	Module  string // module path, from go.mod
+	DataDir string // data directory, relative to Root with forward slashes
+	Day     int
+	Title   string // puzzle title, may be empty
+}
+
+var solverTemplate = template.Must(template.New("solver").Parse(`// Package day{{.Day}} solves day {{.Day}} of Advent of Code 2024{{if .Title}}: {{.Title}}{{end}}.
+package day{{.Day}}
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"{{.Module}}/aoc"
+	"{{.Module}}/parse"
+)
+
+// ParseInput parses the puzzle input into its lines.
+func ParseInput(reader io.Reader) ([]string, error) {
+	var input []string
+	lines := parse.NewLines(reader)
+	for lines.Next() {
+		input = append(input, lines.Line().Text)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// Solver solves day {{.Day}} and is registered with the aoc runner.
+type Solver struct{}
+
+func (Solver) Parse(r io.Reader) ([]string, error) {
+	input, err := ParseInput(r)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing input: %w", err)
+	}
+	return input, nil
+}
+
+func (Solver) Part1(ctx context.Context, input []string) (aoc.Answer, error) {
+	return aoc.Answer{}, errors.New("part 1 is not implemented")
+}
+
+func (Solver) Part2(ctx context.Context, input []string) (aoc.Answer, error) {
+	return aoc.Answer{}, errors.New("part 2 is not implemented")
+}
+
+func init() {
+	aoc.Register[[]string]({{.Day}}, Solver{})
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package day{{.Day}}
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"{{.Module}}/aoc"
+	"{{.Module}}/aoctest"
+)
+
+// example is the example input from the puzzle description.
+const example = ` + "``" + `
+
+func TestParseInput(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected []string
+	}{
+		{"a\nb", []string{"a", "b"}},
+	}
+
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			result, err := ParseInput(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("got %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParts(t *testing.T) {
+	var tests = []struct {
+		part     int
+		input    string
+		expected string
+	}{
+		// {1, example, "answer from the puzzle description"},
+	}
+
+	d, err := aoc.Lookup({{.Day}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, tt := range tests {
+		testname := fmt.Sprintf("test_case_%v", idx)
+		t.Run(testname, func(t *testing.T) {
+			answer, err := d.Solve(context.Background(), tt.part, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("got error %v, expected nil", err)
+			}
+			if answer.String() != tt.expected {
+				t.Errorf("got %v, expected %v", answer, tt.expected)
+			}
+		})
+	}
+}
+
+func FuzzParseInput(f *testing.F) {
+	aoctest.Seed(f, filepath.Join("..", "..", {{printf "%q" .DataDir}}), {{.Day}})
+
+	d, err := aoc.Lookup({{.Day}})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		input, err := ParseInput(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		// Any input the parser accepts must be solved or rejected with an error, never panic.
+		for _, part := range d.Parts() {
+			d.Part(context.Background(), part, input)
+		}
+	})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	benchmarkPart(b, 1)
+}
+
+func BenchmarkPart2(b *testing.B) {
+	benchmarkPart(b, 2)
+}
+
+// benchmarkPart benchmarks solving a part of the example, once it is implemented.
+func benchmarkPart(b *testing.B, part int) {
+	d, err := aoc.Lookup({{.Day}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	input, err := d.Parse(strings.NewReader(example))
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := d.Part(context.Background(), part, input); err != nil {
+		b.Skip(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Part(context.Background(), part, input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+`))
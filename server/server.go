@@ -0,0 +1,277 @@
+// Package server exposes the registered solutions over HTTP.
+//
+//	GET  /days                 lists the registered days and their parts
+//	POST /day/{n}/part/{p}     solves a part, with the puzzle input as the body
+//
+// Responses are JSON. Inputs are limited in size, and each request is given a
+// deadline so a slow solution can't hold a client forever. Only a few inputs
+// are solved at once, and none while a solution which ignored its deadline is
+// still running, so requests can't pile up work in the background.
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/diag"
+)
+
+const (
+	// DefaultMaxInputSize is the default limit on the size of a request body.
+	DefaultMaxInputSize = 1 << 20
+
+	// DefaultTimeout is the default time allowed to parse and solve an input.
+	DefaultTimeout = 30 * time.Second
+
+	// interruptGracePeriod is how long a timed out part is given to stop.
+	interruptGracePeriod = 100 * time.Millisecond
+)
+
+// Server is an http.Handler serving the registered solutions.
+type Server struct {
+	MaxInputSize int64
+	Timeout      time.Duration
+	MaxSolves    int // inputs parsed and solved at once; further requests get a 503
+
+	mu        sync.Mutex
+	running   int // solves running, including abandoned ones
+	abandoned int // solves which timed out, but haven't stopped yet
+}
+
+// New returns a server with the default limits, solving as many inputs at
+// once as there are CPUs.
+func New() *Server {
+	return &Server{MaxInputSize: DefaultMaxInputSize, Timeout: DefaultTimeout, MaxSolves: runtime.NumCPU()}
+}
+
+// DayInfo describes a registered day.
+type DayInfo struct {
+	Day   int   `json:"day"`
+	Parts []int `json:"parts"`
+}
+
+// Answer is the response to a solved part. It uses the same field names as
+// the output of `aoc run --format json`.
+type Answer struct {
+	Day             int            `json:"day"`
+	Part            int            `json:"part"`
+	Answer          string         `json:"answer"`
+	ParseDurationNS int64          `json:"parse_duration_ns"`
+	DurationNS      int64          `json:"duration_ns"`
+	InputSHA256     string         `json:"input_sha256"`
+	Meta            map[string]any `json:"meta,omitempty"`
+}
+
+// Error is the response to a failed request.
+type Error struct {
+	Error      string           `json:"error"`
+	Diagnostic *diag.Diagnostic `json:"diagnostic,omitempty"` // for malformed input
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Routing is done by hand, as patterns with methods and wildcards need Go 1.22.
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "days":
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, http.MethodGet)
+			return
+		}
+		s.handleDays(w)
+
+	case len(segments) == 4 && segments[0] == "day" && segments[2] == "part":
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		day, dayErr := strconv.Atoi(segments[1])
+		part, partErr := strconv.Atoi(segments[3])
+		if dayErr != nil || partErr != nil {
+			writeError(w, http.StatusNotFound, &Error{Error: "invalid day or part"})
+			return
+		}
+		s.handleSolve(w, r, day, part)
+
+	default:
+		writeError(w, http.StatusNotFound, &Error{Error: fmt.Sprintf("no such endpoint %s", r.URL.Path)})
+	}
+}
+
+func (s *Server) handleDays(w http.ResponseWriter) {
+	days := []DayInfo{}
+	for _, day := range aoc.Days() {
+		d, err := aoc.Lookup(day)
+		if err != nil {
+			continue
+		}
+		days = append(days, DayInfo{Day: day, Parts: d.Parts()})
+	}
+	writeJSON(w, http.StatusOK, days)
+}
+
+func (s *Server) handleSolve(w http.ResponseWriter, r *http.Request, day int, part int) {
+	d, err := aoc.Lookup(day)
+	if err != nil {
+		writeError(w, http.StatusNotFound, &Error{Error: err.Error()})
+		return
+	}
+	if part < 1 || part > len(d.Parts()) {
+		writeError(w, http.StatusNotFound, &Error{Error: fmt.Sprintf("day %d has no part %d", day, part)})
+		return
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.MaxInputSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, &Error{Error: fmt.Sprintf("input is larger than %d bytes", tooLarge.Limit)})
+		} else {
+			writeError(w, http.StatusBadRequest, &Error{Error: err.Error()})
+		}
+		return
+	}
+
+	if errResponse := s.acquire(); errResponse != nil {
+		writeError(w, http.StatusServiceUnavailable, errResponse)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.Timeout)
+	defer cancel()
+
+	answer, status, errResponse := s.solve(ctx, d, part, input)
+	if errResponse != nil {
+		writeError(w, status, errResponse)
+		return
+	}
+	writeJSON(w, http.StatusOK, answer)
+}
+
+// acquire reserves a slot to solve an input, which is released when the
+// solve finishes (even after its request has timed out). It fails while all
+// MaxSolves slots are taken, or while a timed out solve is still running.
+func (s *Server) acquire() *Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.abandoned > 0 {
+		return &Error{Error: "busy: waiting for timed out solves to stop"}
+	}
+	if s.running >= s.MaxSolves {
+		return &Error{Error: fmt.Sprintf("busy: already solving the maximum of %d inputs at once", s.MaxSolves)}
+	}
+	s.running++
+	return nil
+}
+
+// outcome is the result of a solve: an answer, or the HTTP status and error
+// response.
+type outcome struct {
+	answer *Answer
+	status int
+	err    *Error
+}
+
+// solve parses and solves the input in a slot reserved with acquire, giving
+// up when ctx is done.
+//
+// Parts which check ctx stop and report their progress. Any which don't (and
+// parsing, which can't be interrupted) are given up on and keep running in the
+// background, holding their slot and turning new requests away until they
+// finish.
+func (s *Server) solve(ctx context.Context, d *aoc.Day, part int, input []byte) (*Answer, int, *Error) {
+	done := make(chan outcome, 1)
+	var finished, abandoned bool // guarded by s.mu
+
+	go func() {
+		o := solveInput(ctx, d, part, input)
+
+		// Released before the outcome is sent, so the next request can have the slot.
+		s.mu.Lock()
+		s.running--
+		if abandoned {
+			s.abandoned--
+		}
+		finished = true
+		s.mu.Unlock()
+
+		done <- o
+	}()
+
+	select {
+	case o := <-done:
+		return o.answer, o.status, o.err
+	case <-ctx.Done():
+	}
+
+	// Give a part which checks ctx a moment to report its progress.
+	select {
+	case o := <-done:
+		return o.answer, o.status, o.err
+	case <-time.After(interruptGracePeriod):
+	}
+
+	s.mu.Lock()
+	if !finished {
+		abandoned = true
+		s.abandoned++
+	}
+	s.mu.Unlock()
+	return nil, http.StatusGatewayTimeout, &Error{Error: fmt.Sprintf("day %d part %d did not finish in time", d.Number, part)}
+}
+
+// solveInput parses and solves the input.
+func solveInput(ctx context.Context, d *aoc.Day, part int, input []byte) outcome {
+	sum := sha256.Sum256(input)
+	result := &Answer{Day: d.Number, Part: part, InputSHA256: hex.EncodeToString(sum[:])}
+
+	start := time.Now()
+	parsed, err := d.Parse(bytes.NewReader(input))
+	result.ParseDurationNS = time.Since(start).Nanoseconds()
+	if err != nil {
+		errResponse := &Error{Error: err.Error()}
+		if diagnostic, ok := diag.FromError(err); ok {
+			errResponse.Diagnostic = diagnostic
+		}
+		return outcome{nil, http.StatusUnprocessableEntity, errResponse}
+	}
+
+	start = time.Now()
+	answer, err := d.Part(ctx, part, parsed)
+	result.DurationNS = time.Since(start).Nanoseconds()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return outcome{nil, http.StatusGatewayTimeout, &Error{Error: err.Error()}}
+	} else if err != nil {
+		return outcome{nil, http.StatusUnprocessableEntity, &Error{Error: err.Error()}}
+	}
+	result.Answer = answer.String()
+	result.Meta = answer.Meta
+	return outcome{result, http.StatusOK, nil}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, errResponse *Error) {
+	writeJSON(w, status, errResponse)
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, allowed string) {
+	w.Header().Set("Allow", allowed)
+	writeError(w, http.StatusMethodNotAllowed, &Error{Error: fmt.Sprintf("method not allowed (expected %s)", allowed)})
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Andoryuuta/AdventOfCode2024/aoc"
+	"github.com/Andoryuuta/AdventOfCode2024/parse"
+)
+
+// sumSolver sums a line of unsigned integers separated by single spaces.
+type sumSolver struct{}
+
+func (sumSolver) Parse(r io.Reader) ([]uint64, error) {
+	lines := parse.NewLines(r)
+	if !lines.Next() {
+		return nil, errors.New("empty input")
+	}
+	return lines.Line().Uints(" ")
+}
+
+func (sumSolver) Part1(ctx context.Context, input []uint64) (aoc.Answer, error) {
+	var sum uint64
+	for _, v := range input {
+		sum += v
+	}
+	return aoc.IntAnswer(sum).WithMeta("count", len(input)), nil
+}
+
+func (sumSolver) Part2(ctx context.Context, input []uint64) (aoc.Answer, error) {
+	return aoc.Answer{}, errors.New("part 2 is not implemented")
+}
+
+// blockingSolver never finishes part 1 until release is closed, and part 2
+// waits until it is cancelled.
+type blockingSolver struct{}
+
+var release = make(chan struct{})
+
+func (blockingSolver) Parse(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+func (blockingSolver) Part1(ctx context.Context, input string) (aoc.Answer, error) {
+	<-release
+	return aoc.IntAnswer(len(input)), nil
+}
+
+func (blockingSolver) Part2(ctx context.Context, input string) (aoc.Answer, error) {
+	<-ctx.Done()
+	return aoc.Answer{}, aoc.Interrupted(ctx, "waiting")
+}
+
+func init() {
+	aoc.Register[[]uint64](24, sumSolver{})
+	aoc.Register[string](25, blockingSolver{})
+}
+
+func TestServer(t *testing.T) {
+	s := New()
+	s.MaxInputSize = 16
+	s.Timeout = 20 * time.Millisecond
+
+	var tests = []struct {
+		name             string
+		method           string
+		path             string
+		body             string
+		expectedStatus   int
+		expectedResponse string // a substring of the JSON response
+	}{
+		{"list days", "GET", "/days", "", 200, `{"day":24,"parts":[1,2]}`},
+		{"solve", "POST", "/day/24/part/1", "1 2 3", 200, `"answer":"6"`},
+		{"answer metadata", "POST", "/day/24/part/1", "1 2 3", 200, `"meta":{"count":3}`},
+		{"empty input", "POST", "/day/24/part/1", "", 422, `"error":"empty input"`},
+		{"malformed input", "POST", "/day/24/part/1", "1 x", 422, `"diagnostic":{"line":1,"column":3`},
+		{"part error", "POST", "/day/24/part/2", "1", 422, "not implemented"},
+		{"input too large", "POST", "/day/24/part/1", strings.Repeat("1 ", 10), 413, "larger than 16 bytes"},
+		{"timeout with progress", "POST", "/day/25/part/2", "x", 504, "interrupted after waiting"},
+		{"unknown day", "POST", "/day/3/part/1", "", 404, "no solution registered for day 3"},
+		{"unknown part", "POST", "/day/24/part/3", "", 404, "has no part 3"},
+		{"invalid day", "POST", "/day/x/part/1", "", 404, "invalid day"},
+		{"wrong method", "GET", "/day/24/part/1", "", 405, "expected POST"},
+		{"unknown endpoint", "GET", "/", "", 404, "no such endpoint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("got status %d, expected %d", rec.Code, tt.expectedStatus)
+			}
+			if !json.Valid(rec.Body.Bytes()) {
+				t.Errorf("got invalid JSON response %q", rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.expectedResponse) {
+				t.Errorf("got response %s, expected it to contain %s", rec.Body, tt.expectedResponse)
+			}
+		})
+	}
+}
+
+// waitForSolves waits until no solve is running, even in the background.
+func waitForSolves(t *testing.T, s *Server) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		running := s.running
+		s.mu.Unlock()
+		if running == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d solves still running, expected none", running)
+		}
+	}
+}
+
+func TestServerTimedOutSolve(t *testing.T) {
+	release = make(chan struct{})
+	s := New()
+	s.Timeout = 20 * time.Millisecond
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("POST", "/day/25/part/1", strings.NewReader("x")))
+	if rec.Code != 504 || !strings.Contains(rec.Body.String(), "did not finish in time") {
+		t.Errorf("got status %d, response %s, expected a timeout", rec.Code, rec.Body)
+	}
+
+	// Nothing else is solved while the part which ignored its deadline runs on.
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("POST", "/day/24/part/1", strings.NewReader("1 2")))
+	if rec.Code != 503 || !strings.Contains(rec.Body.String(), "waiting for timed out solves") {
+		t.Errorf("got status %d, response %s, expected 503", rec.Code, rec.Body)
+	}
+
+	close(release)
+	waitForSolves(t, s)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("POST", "/day/24/part/1", strings.NewReader("1 2")))
+	if rec.Code != 200 {
+		t.Errorf("got status %d, response %s, expected 200 once the part stopped", rec.Code, rec.Body)
+	}
+}
+
+func TestServerMaxSolves(t *testing.T) {
+	s := New()
+	s.MaxSolves = 1
+
+	// Part 2 of day 25 runs until its request is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/day/25/part/2", strings.NewReader("x")).WithContext(ctx))
+	}()
+	for {
+		s.mu.Lock()
+		running := s.running
+		s.mu.Unlock()
+		if running == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("POST", "/day/24/part/1", strings.NewReader("1 2")))
+	if rec.Code != 503 || !strings.Contains(rec.Body.String(), "maximum of 1 inputs") {
+		t.Errorf("got status %d, response %s, expected 503", rec.Code, rec.Body)
+	}
+
+	cancel()
+	<-done
+	waitForSolves(t, s)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("POST", "/day/24/part/1", strings.NewReader("1 2")))
+	if rec.Code != 200 {
+		t.Errorf("got status %d, response %s, expected 200 once the first solve stopped", rec.Code, rec.Body)
+	}
+}
+
+func TestServerAnswer(t *testing.T) {
+	rec := httptest.NewRecorder()
+	New().ServeHTTP(rec, httptest.NewRequest("POST", "/day/24/part/1", strings.NewReader("4 5")))
+
+	var answer Answer
+	if err := json.NewDecoder(rec.Body).Decode(&answer); err != nil {
+		t.Fatal(err)
+	}
+	if answer.Day != 24 || answer.Part != 1 || answer.Answer != "9" || len(answer.InputSHA256) != 64 {
+		t.Errorf("got %+v, expected day 24 part 1 answer 9 with the input hash", answer)
+	}
+	if rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("got content type %q, expected application/json", rec.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,228 @@
+// Package vault encrypts personal puzzle inputs so they can be committed,
+// as Advent of Code asks that inputs aren't published.
+//
+// An input file such as challenge_data/day6/input is sealed into
+// challenge_data/day6/input.vault, encrypted with AES-256-GCM under a key
+// derived from a passphrase with PBKDF2-HMAC-SHA256. ReadFile reads an input
+// from whichever of the two exists, so the runner works the same either way.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Ext is the extension of sealed files, appended to the input's name.
+const Ext = ".vault"
+
+// DefaultIterations is the PBKDF2 iteration count for new files (the OWASP
+// recommendation for PBKDF2-HMAC-SHA256).
+const DefaultIterations = 600_000
+
+// sealIterations is the iteration count used by Seal (lowered by tests).
+var sealIterations = DefaultIterations
+
+const (
+	formatVersion = 1
+	kdfName       = "pbkdf2-sha256"
+	saltSize      = 16
+	keySize       = 32 // AES-256
+)
+
+var (
+	// ErrNoPassphrase is returned when no passphrase has been configured.
+	ErrNoPassphrase = errors.New("no vault passphrase: set AOC_VAULT_PASSPHRASE or write it to the aoc/vault_passphrase file in the user config directory")
+
+	// ErrWrongPassphrase is returned when a file can't be decrypted, either
+	// because of the passphrase or because the file was modified.
+	ErrWrongPassphrase = errors.New("cannot decrypt vault file: wrong passphrase, or the file is corrupt")
+)
+
+// sealedFile is the on-disk format of a sealed file. It's JSON so that the
+// parameters needed to open it are self-describing.
+type sealedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Seal encrypts the plaintext with a key derived from the passphrase.
+func Seal(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, salt, sealIterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := sealedFile{
+		Version:    formatVersion,
+		KDF:        kdfName,
+		Iterations: sealIterations,
+		Salt:       salt,
+		Nonce:      nonce,
+	}
+	sealed.Ciphertext = aead.Seal(nil, nonce, plaintext, sealed.additionalData())
+	data, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Open decrypts data returned by Seal.
+func Open(data []byte, passphrase string) ([]byte, error) {
+	var sealed sealedFile
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("invalid vault file: %v", err)
+	}
+	if sealed.Version != formatVersion || sealed.KDF != kdfName {
+		return nil, fmt.Errorf("unsupported vault file (version %d, kdf %q)", sealed.Version, sealed.KDF)
+	}
+	if sealed.Iterations < 1 || len(sealed.Salt) == 0 {
+		return nil, errors.New("invalid vault file: missing key derivation parameters")
+	}
+
+	aead, err := newAEAD(passphrase, sealed.Salt, sealed.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid vault file: bad nonce")
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, sealed.additionalData())
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// additionalData authenticates the key derivation parameters along with the
+// ciphertext, so they can't be tampered with (e.g. to lower the iterations).
+func (s *sealedFile) additionalData() []byte {
+	return []byte(fmt.Sprintf("aoc-vault/%d/%s/%d", s.Version, s.KDF, s.Iterations))
+}
+
+func newAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, ErrNoPassphrase
+	}
+	block, err := aes.NewCipher(pbkdf2([]byte(passphrase), salt, iterations, keySize))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2 derives a key of keyLen bytes with PBKDF2-HMAC-SHA256 (RFC 8018).
+// It's implemented here as the standard library only gained it in Go 1.24.
+func pbkdf2(password []byte, salt []byte, iterations int, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+
+	key := make([]byte, 0, (keyLen+hashLen-1)/hashLen*hashLen)
+	var blockIndex [4]byte
+	u := make([]byte, hashLen)
+	for block := uint32(1); len(key) < keyLen; block++ {
+		// U1 = PRF(password, salt || INT(block)); T = U1 ^ U2 ^ ... ^ Uc
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(blockIndex[:], block)
+		prf.Write(blockIndex[:])
+		u = prf.Sum(u[:0])
+
+		t := make([]byte, hashLen)
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+// PassphraseFile returns the default location of the passphrase file.
+func PassphraseFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "aoc", "vault_passphrase"), nil
+}
+
+// LoadPassphrase returns the passphrase from the AOC_VAULT_PASSPHRASE
+// environment variable, falling back to the passphrase file.
+func LoadPassphrase() (string, error) {
+	if passphrase := os.Getenv("AOC_VAULT_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+
+	path, err := PassphraseFile()
+	if err != nil {
+		return "", ErrNoPassphrase
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoPassphrase
+	} else if err != nil {
+		return "", err
+	}
+
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", ErrNoPassphrase
+	}
+	return passphrase, nil
+}
+
+// ReadFile reads an input file, or if it doesn't exist, decrypts its sealed
+// copy (path + Ext) with the configured passphrase.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return data, err
+	}
+
+	sealed, sealedErr := os.ReadFile(path + Ext)
+	if errors.Is(sealedErr, os.ErrNotExist) {
+		// Report the missing input, rather than its missing sealed copy.
+		return nil, err
+	} else if sealedErr != nil {
+		return nil, sealedErr
+	}
+
+	passphrase, err := LoadPassphrase()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path+Ext, err)
+	}
+	data, err = Open(sealed, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path+Ext, err)
+	}
+	return data, nil
+}
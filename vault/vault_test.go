@@ -0,0 +1,113 @@
+package vault
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	// Keep the tests fast; the iteration count is stored in each sealed file.
+	sealIterations = 1000
+}
+
+func TestPBKDF2(t *testing.T) {
+	// Test vectors from RFC 7914, section 11.
+	var tests = []struct {
+		password   string
+		salt       string
+		iterations int
+		keyLen     int
+		expected   string
+	}{
+		{"passwd", "salt", 1, 64, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+		{"Password", "NaCl", 80000, 64, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+		{"pass", "salty", 3, 40, "3f1fa00fbca64254656ef6c57bb35f0955d5fb6c2c0dc9ea9ac0dbe3a16aae25b02a5b908fdf5aff"},
+	}
+
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2([]byte(tt.password), []byte(tt.salt), tt.iterations, tt.keyLen))
+		if got != tt.expected {
+			t.Errorf("pbkdf2(%q, %q, %d): got %s, expected %s", tt.password, tt.salt, tt.iterations, got, tt.expected)
+		}
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	input := []byte("3   4\n4   3\n")
+	sealed, err := Seal(input, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opened, err := Open(sealed, "correct horse")
+	if err != nil || string(opened) != string(input) {
+		t.Errorf("got %q (err: %v), expected %q", opened, err, input)
+	}
+
+	if _, err := Open(sealed, "battery staple"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("got error %v for the wrong passphrase, expected %v", err, ErrWrongPassphrase)
+	}
+
+	// The key derivation parameters are authenticated along with the input.
+	var file sealedFile
+	if err := json.Unmarshal(sealed, &file); err != nil {
+		t.Fatal(err)
+	}
+	file.Iterations = 1
+	tampered, _ := json.Marshal(file)
+	if _, err := Open(tampered, "correct horse"); err == nil {
+		t.Errorf("got nil error for tampered iterations, expected !nil")
+	}
+
+	if again, _ := Seal(input, "correct horse"); string(again) == string(sealed) {
+		t.Errorf("got identical output from sealing twice, expected a fresh salt and nonce")
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	t.Setenv("AOC_VAULT_PASSPHRASE", "correct horse")
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "plain")
+	if err := os.WriteFile(plain, []byte("plain input"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sealedPath := filepath.Join(dir, "input")
+	sealed, err := Seal([]byte("sealed input"), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sealedPath+Ext, sealed, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		path     string
+		expected string
+		notExist bool
+	}{
+		{"plain file", plain, "plain input", false},
+		{"sealed file", sealedPath, "sealed input", false},
+		{"missing file", filepath.Join(dir, "missing"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ReadFile(tt.path)
+			if tt.notExist {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("got error %v, expected %v", err, os.ErrNotExist)
+				}
+				return
+			}
+			if err != nil || string(data) != tt.expected {
+				t.Errorf("got %q (err: %v), expected %q", data, err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package watch polls files for changes. Polling is used rather than OS
+// notifications so it works the same everywhere, including editors which
+// save by replacing the file.
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultInterval is the default time between polls.
+const DefaultInterval = 500 * time.Millisecond
+
+// fileState is what's compared between polls to detect a change.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher polls the files matching a set of glob patterns.
+type Watcher struct {
+	Patterns []string // filepath.Match patterns, e.g. "days/day5/*.go"
+	Interval time.Duration
+
+	last map[string]fileState // nil before the first poll
+}
+
+// New returns a watcher for the files matching the patterns.
+func New(patterns ...string) *Watcher {
+	return &Watcher{Patterns: patterns, Interval: DefaultInterval}
+}
+
+// Poll returns the files which were created, modified or removed since the
+// previous poll, in sorted order. The first poll returns every matching file.
+func (w *Watcher) Poll() ([]string, error) {
+	current := map[string]fileState{}
+	for _, pattern := range w.Patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				// Removed since the glob, or not a file; either way there's nothing to watch.
+				continue
+			}
+			current[path] = fileState{info.ModTime(), info.Size()}
+		}
+	}
+
+	var changed []string
+	for path, state := range current {
+		if last, ok := w.last[path]; !ok || last != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range w.last {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+
+	w.last = current
+	return changed, nil
+}
+
+// Wait polls until a file has changed, and returns the changed files. It
+// returns immediately on the first call.
+func (w *Watcher) Wait(ctx context.Context) ([]string, error) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	first := w.last == nil
+	for {
+		changed, err := w.Poll()
+		if err != nil || len(changed) > 0 || first {
+			return changed, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
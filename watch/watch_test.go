@@ -0,0 +1,72 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	a := write("a.go", "package a")
+	b := write("b.go", "package b")
+	write("input.txt", "not watched")
+
+	w := New(filepath.Join(dir, "*.go"))
+
+	var tests = []struct {
+		name     string
+		change   func()
+		expected []string
+	}{
+		{"first poll returns every file", func() {}, []string{a, b}},
+		{"no changes", func() {}, nil},
+		{"modified size", func() { write("a.go", "package a // changed") }, []string{a}},
+		{"modified time", func() {
+			if err := os.Chtimes(b, time.Now(), time.Now().Add(time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+		}, []string{b}},
+		{"created and removed", func() {
+			write("c.go", "package c")
+			os.Remove(a)
+		}, []string{a, filepath.Join(dir, "c.go")}},
+		{"unwatched file", func() { write("input.txt", "still not watched") }, nil},
+	}
+
+	for _, tt := range tests {
+		tt.change()
+		changed, err := w.Poll()
+		if err != nil {
+			t.Fatalf("%s: got error %v, expected nil", tt.name, err)
+		}
+		if !slices.Equal(changed, tt.expected) {
+			t.Errorf("%s: got %v, expected %v", tt.name, changed, tt.expected)
+		}
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	w := New(filepath.Join(t.TempDir(), "*"))
+	w.Interval = time.Millisecond
+
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Fatalf("got error %v on first wait, expected nil", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := w.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+}